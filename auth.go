@@ -2,10 +2,11 @@ package stockxgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -16,23 +17,78 @@ var (
 )
 
 func (s *stockXClient) Authenticate() error {
+	return s.AuthenticateContext(context.Background())
+}
+
+// AuthenticateContext exchanges the authorization code for a session and
+// starts refreshing it in the background. Once the client holds a session,
+// further calls only make sure the background refresh is running.
+func (s *stockXClient) AuthenticateContext(ctx context.Context) error {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	if s.lifecycle.Err() != nil {
+		return ErrClientClosed
+	}
+
+	if s.GetAccessToken() == "" && s.tokenStore != nil {
+		stored, err := s.tokenStore.Load(ctx)
+		if err != nil && !errors.Is(err, ErrNoSession) {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+		if err == nil && stored.AccessToken != "" {
+			s.setSession(stored.withExpiry())
+		}
+	}
+
+	if s.GetAccessToken() != "" {
+		s.startRefreshLoop()
+		return nil
+	}
+
+	return s.exchangeCode(ctx, s.code, s.codeVerifier)
+}
+
+func (s *stockXClient) ExchangeCode(code, codeVerifier string) error {
+	return s.ExchangeCodeContext(context.Background(), code, codeVerifier)
+}
+
+// ExchangeCodeContext exchanges an authorization code for a new session,
+// replacing any existing one, and starts refreshing it in the background.
+// codeVerifier is only needed when the authorize URL carried a PKCE challenge.
+func (s *stockXClient) ExchangeCodeContext(ctx context.Context, code, codeVerifier string) error {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	if s.lifecycle.Err() != nil {
+		return ErrClientClosed
+	}
+
+	return s.exchangeCode(ctx, code, codeVerifier)
+}
+
+func (s *stockXClient) exchangeCode(ctx context.Context, code, codeVerifier string) error {
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("client_id", s.clientID)
 	data.Set("client_secret", s.clientSecret)
-	data.Set("code", s.code)
-	data.Set("redirect_uri", "https://localhost:3000")
+	data.Set("code", code)
+	data.Set("redirect_uri", s.redirectURI)
+
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
 
-	req, err := http.NewRequest(http.MethodPost, AuthEndpoint, bytes.NewBufferString(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint(AuthEndpoint), bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.send(req)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to authenticate: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -42,50 +98,111 @@ func (s *stockXClient) Authenticate() error {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to authenticate: %s", string(body))
-	}
-
 	var authResp AuthResponse
 	if err := json.Unmarshal(body, &authResp); err != nil {
 		return err
 	}
 
-	s.session.AccessToken = authResp.AccessToken
-	s.session.RefreshToken = authResp.RefreshToken
-	s.session.ExpiresIn = authResp.ExpiresIn
+	s.setSession(Session{
+		AccessToken:  authResp.AccessToken,
+		RefreshToken: authResp.RefreshToken,
+		ExpiresIn:    authResp.ExpiresIn,
+		ExpiresAt:    expiresAt(authResp.ExpiresIn),
+	})
+
+	if err := s.saveSession(ctx); err != nil {
+		return err
+	}
+
+	s.startRefreshLoop()
+
+	return nil
+}
+
+// startRefreshLoop starts the background refresh unless it is already
+// running. It stops when the client is closed. Without a known expiry there is
+// nothing to schedule against, so the token is only refreshed once a request
+// is rejected with 401.
+func (s *stockXClient) startRefreshLoop() {
+	s.loopMu.Lock()
+	defer s.loopMu.Unlock()
+
+	session := s.GetSession()
+	if s.loopRunning || session.RefreshToken == "" || session.ExpiresAt.IsZero() || s.lifecycle.Err() != nil {
+		return
+	}
+
+	s.loopRunning = true
+	s.loopWG.Add(1)
 
 	go func() {
-		// automatically refresh the token when it expires
-		for {
-			time.Sleep(time.Duration(s.session.ExpiresIn) * time.Second)
-			if err := s.RefreshToken(); err != nil {
-				log.Printf("failed to refresh token: %s", err)
-			}
-		}
+		defer s.loopWG.Done()
+		s.refreshLoop()
 	}()
+}
 
-	return nil
+// refreshLoop refreshes the access token shortly before it expires.
+func (s *stockXClient) refreshLoop() {
+	for {
+		// The last refresh may not have said when the token expires. Checked
+		// under loopMu so a refresh that does cannot miss restarting the loop.
+		s.loopMu.Lock()
+		expiry := s.GetSession().ExpiresAt
+		if expiry.IsZero() {
+			s.loopRunning = false
+			s.loopMu.Unlock()
+			return
+		}
+		s.loopMu.Unlock()
+
+		wait := time.Until(expiry.Add(-refreshSkew))
+		if wait < minRefreshInterval {
+			wait = minRefreshInterval
+		}
+
+		if err := sleepContext(s.lifecycle, wait); err != nil {
+			return
+		}
+
+		if err := s.refresh(s.lifecycle); err != nil && s.lifecycle.Err() == nil && s.onRefreshError != nil {
+			s.onRefreshError(err)
+		}
+	}
 }
 
 func (s *stockXClient) RefreshToken() error {
+	return s.RefreshTokenContext(context.Background())
+}
+
+// RefreshTokenContext exchanges the refresh token for a new access token.
+// Concurrent calls share a single request to the token endpoint.
+func (s *stockXClient) RefreshTokenContext(ctx context.Context) error {
+	return s.refresh(ctx)
+}
+
+func (s *stockXClient) refreshToken(ctx context.Context) error {
+	adopted, err := s.adoptStoredSession(ctx)
+	if err != nil || adopted {
+		return err
+	}
+
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("client_id", s.clientID)
 	data.Set("client_secret", s.clientSecret)
-	data.Set("refresh_token", s.session.RefreshToken)
-	data.Set("audience", "gateway.stockx.com")
+	data.Set("refresh_token", s.GetSession().RefreshToken)
+	data.Set("audience", DefaultAudience)
 
-	req, err := http.NewRequest(http.MethodPost, AuthEndpoint, bytes.NewBufferString(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint(AuthEndpoint), bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.send(req)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to refresh token: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -95,31 +212,33 @@ func (s *stockXClient) RefreshToken() error {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to refresh token: %s", string(body))
-	}
-
 	var refreshResp RefreshResponse
 	if err := json.Unmarshal(body, &refreshResp); err != nil {
 		return err
 	}
 
+	s.sessionMu.Lock()
 	s.session.AccessToken = refreshResp.AccessToken
 	s.session.ExpiresIn = refreshResp.ExpiresIn
+	s.session.ExpiresAt = expiresAt(refreshResp.ExpiresIn)
+	if refreshResp.RefreshToken != "" {
+		s.session.RefreshToken = refreshResp.RefreshToken
+	}
+	s.sessionMu.Unlock()
 
-	return nil
+	return s.saveSession(ctx)
 }
 
 func (s *stockXClient) GetAccessToken() string {
-	return s.session.AccessToken
+	return s.GetSession().AccessToken
 }
 
 func (s *stockXClient) GetRefreshToken() string {
-	return s.session.RefreshToken
+	return s.GetSession().RefreshToken
 }
 
 func (s *stockXClient) GetExpiresIn() int {
-	return s.session.ExpiresIn
+	return s.GetSession().ExpiresIn
 }
 
 type AuthResponse struct {
@@ -132,9 +251,10 @@ type AuthResponse struct {
 }
 
 type RefreshResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
-	IDToken     string `json:"id_token"`
-	Scope       string `json:"scope"`
-	TokenType   string `json:"token_type"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+	TokenType    string `json:"token_type"`
 }
@@ -0,0 +1,132 @@
+package stockxgo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var (
+	AuthorizeEndpoint = "https://accounts.stockx.com/authorize"
+)
+
+const (
+	DefaultRedirectURI = "https://localhost:3000"
+	DefaultAudience    = "gateway.stockx.com"
+	DefaultScope       = "offline_access openid"
+)
+
+var ErrStateMismatch = errors.New("oauth state mismatch")
+
+// AuthorizationURLRequest holds the parameters of the authorize URL.
+type AuthorizationURLRequest struct {
+	Scope               string
+	Audience            string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+type AuthorizationURLOption func(*AuthorizationURLRequest)
+
+// WithAuthorizationScope overrides the requested scopes.
+// Defaults to "offline_access openid".
+func WithAuthorizationScope(scopes ...string) AuthorizationURLOption {
+	return func(r *AuthorizationURLRequest) {
+		r.Scope = strings.Join(scopes, " ")
+	}
+}
+
+// WithAuthorizationAudience overrides the requested audience.
+// Defaults to "gateway.stockx.com".
+func WithAuthorizationAudience(audience string) AuthorizationURLOption {
+	return func(r *AuthorizationURLRequest) {
+		r.Audience = audience
+	}
+}
+
+// WithPKCE adds the code challenge of pkce to the authorize URL. The
+// matching verifier must be passed to ExchangeCode.
+func WithPKCE(pkce PKCE) AuthorizationURLOption {
+	return func(r *AuthorizationURLRequest) {
+		r.CodeChallenge = pkce.Challenge
+		r.CodeChallengeMethod = pkce.Method
+	}
+}
+
+// AuthorizationURL builds the URL the user has to visit to grant access. The
+// user is redirected back to the client's redirect URI with the code and
+// state as query parameters.
+func (s *stockXClient) AuthorizationURL(state string, opts ...AuthorizationURLOption) string {
+	request := &AuthorizationURLRequest{
+		Scope:    DefaultScope,
+		Audience: DefaultAudience,
+	}
+
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("response_type", "code")
+	queryParams.Add("client_id", s.clientID)
+	queryParams.Add("redirect_uri", s.redirectURI)
+	queryParams.Add("scope", request.Scope)
+	queryParams.Add("audience", request.Audience)
+	queryParams.Add("state", state)
+
+	if request.CodeChallenge != "" {
+		queryParams.Add("code_challenge", request.CodeChallenge)
+		queryParams.Add("code_challenge_method", request.CodeChallengeMethod)
+	}
+
+	return s.endpoint(AuthorizeEndpoint) + "?" + queryParams.Encode()
+}
+
+// PKCE is a proof key for code exchange (RFC 7636).
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	Method    string
+}
+
+// NewPKCE generates a random code verifier and its S256 challenge.
+func NewPKCE() (PKCE, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return PKCE{}, err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+		Method:    "S256",
+	}, nil
+}
+
+// NewState generates a random value for the OAuth state parameter.
+func NewState() (string, error) {
+	return randomString(24)
+}
+
+// ValidateState checks the state returned on the redirect against the one
+// sent in the authorize URL.
+func ValidateState(expected, actual string) error {
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) != 1 {
+		return ErrStateMismatch
+	}
+	return nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
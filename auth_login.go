@@ -0,0 +1,250 @@
+package stockxgo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+var ErrLoginTimeout = errors.New("timed out waiting for the authorization redirect")
+
+// InteractiveLoginRequest holds the settings of an interactive login.
+type InteractiveLoginRequest struct {
+	Output               io.Writer
+	OpenBrowser          bool
+	Timeout              time.Duration
+	TLSConfig            *tls.Config
+	UsePKCE              bool
+	AuthorizationOptions []AuthorizationURLOption
+}
+
+type InteractiveLoginOption func(*InteractiveLoginRequest)
+
+// WithLoginOutput sets where the authorize URL is printed.
+// Defaults to os.Stderr.
+func WithLoginOutput(w io.Writer) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.Output = w
+	}
+}
+
+// WithLoginOpenBrowser tries to open the authorize URL in the default browser.
+func WithLoginOpenBrowser(open bool) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.OpenBrowser = open
+	}
+}
+
+// WithLoginTimeout sets how long to wait for the redirect.
+// Defaults to 5 minutes.
+func WithLoginTimeout(timeout time.Duration) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.Timeout = timeout
+	}
+}
+
+// WithLoginTLSConfig serves the callback over TLS, which is required when the
+// redirect URI uses https.
+func WithLoginTLSConfig(config *tls.Config) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.TLSConfig = config
+	}
+}
+
+// WithLoginPKCE adds a PKCE challenge to the authorize URL.
+func WithLoginPKCE(usePKCE bool) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.UsePKCE = usePKCE
+	}
+}
+
+// WithLoginAuthorizationOptions passes options on to AuthorizationURL.
+func WithLoginAuthorizationOptions(opts ...AuthorizationURLOption) InteractiveLoginOption {
+	return func(r *InteractiveLoginRequest) {
+		r.AuthorizationOptions = opts
+	}
+}
+
+type loginResult struct {
+	code string
+	err  error
+}
+
+// InteractiveLogin runs the authorization code flow end to end: it listens on
+// the client's loopback redirect URI, prints (and optionally opens) the
+// authorize URL, waits for the redirect, exchanges the code and returns the
+// new session.
+func (s *stockXClient) InteractiveLogin(ctx context.Context, opts ...InteractiveLoginOption) (Session, error) {
+	request := &InteractiveLoginRequest{
+		Output:  os.Stderr,
+		Timeout: 5 * time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	redirect, err := url.Parse(s.redirectURI)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if err := checkLoopback(redirect); err != nil {
+		return Session{}, err
+	}
+
+	if redirect.Scheme == "https" && request.TLSConfig == nil {
+		return Session{}, fmt.Errorf("redirect URI %s uses https: a TLS config is required", s.redirectURI)
+	}
+
+	state, err := NewState()
+	if err != nil {
+		return Session{}, err
+	}
+
+	authOpts := request.AuthorizationOptions
+	var pkce PKCE
+	if request.UsePKCE {
+		if pkce, err = NewPKCE(); err != nil {
+			return Session{}, err
+		}
+		authOpts = append(authOpts, WithPKCE(pkce))
+	}
+
+	listener, err := net.Listen("tcp", listenAddress(redirect))
+	if err != nil {
+		return Session{}, err
+	}
+
+	if request.TLSConfig != nil {
+		listener = tls.NewListener(listener, request.TLSConfig)
+	}
+
+	results := make(chan loginResult, 1)
+	server := &http.Server{
+		Handler:           loginCallbackHandler(redirect.Path, state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := s.AuthorizationURL(state, authOpts...)
+	fmt.Fprintf(request.Output, "Open the following URL to log in to StockX:\n\n%s\n\n", authURL)
+
+	if request.OpenBrowser {
+		if err := openBrowser(authURL); err != nil {
+			fmt.Fprintf(request.Output, "Could not open a browser: %s\n", err)
+		}
+	}
+
+	timer := time.NewTimer(request.Timeout)
+	defer timer.Stop()
+
+	var result loginResult
+	select {
+	case result = <-results:
+	case <-timer.C:
+		return Session{}, ErrLoginTimeout
+	case <-ctx.Done():
+		return Session{}, ctx.Err()
+	}
+
+	if result.err != nil {
+		return Session{}, result.err
+	}
+
+	if err := s.ExchangeCodeContext(ctx, result.code, pkce.Verifier); err != nil {
+		return Session{}, err
+	}
+
+	return s.GetSession(), nil
+}
+
+// loginCallbackHandler delivers the outcome of the redirect carrying state.
+// Requests without the matching state, such as a stale browser tab or a
+// request from another local process, are answered with 400 and do not end
+// the login.
+func loginCallbackHandler(path, state string, results chan<- loginResult) http.Handler {
+	if path == "" {
+		path = "/"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if err := ValidateState(state, query.Get("state")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var result loginResult
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization failed: %s: %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			result.err = errors.New("authorization redirect did not include a code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login complete. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+}
+
+func checkLoopback(redirect *url.URL) error {
+	host := redirect.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return fmt.Errorf("redirect URI host %q is not a loopback address", host)
+}
+
+func listenAddress(redirect *url.URL) string {
+	port := redirect.Port()
+	if port == "" {
+		port = "80"
+		if redirect.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(redirect.Hostname(), port)
+}
+
+func openBrowser(target string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", target).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target).Start()
+	default:
+		return exec.Command("xdg-open", target).Start()
+	}
+}
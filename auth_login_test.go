@@ -0,0 +1,55 @@
+package stockxgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoginCallbackHandler(t *testing.T) {
+	results := make(chan loginResult, 1)
+	handler := loginCallbackHandler("/callback", "expected-state", results)
+
+	call := func(target string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		return rec.Code
+	}
+
+	// Requests that do not carry the login's state are turned away without
+	// ending the login.
+	ignored := []struct {
+		target string
+		status int
+	}{
+		{"/other?state=expected-state&code=abc", http.StatusNotFound},
+		{"/callback", http.StatusBadRequest},
+		{"/callback?code=abc", http.StatusBadRequest},
+		{"/callback?state=wrong-state&code=abc", http.StatusBadRequest},
+		{"/callback?state=wrong-state&error=access_denied", http.StatusBadRequest},
+	}
+	for _, tt := range ignored {
+		if status := call(tt.target); status != tt.status {
+			t.Errorf("GET %s = %d, want %d", tt.target, status, tt.status)
+		}
+	}
+	select {
+	case result := <-results:
+		t.Fatalf("a request without the matching state ended the login with %+v", result)
+	default:
+	}
+
+	if status := call("/callback?state=expected-state&code=the-code"); status != http.StatusOK {
+		t.Errorf("matching redirect = %d, want 200", status)
+	}
+	if result := <-results; result.err != nil || result.code != "the-code" {
+		t.Errorf("result = %+v, want code the-code", result)
+	}
+
+	if status := call("/callback?state=expected-state&error=access_denied&error_description=denied"); status != http.StatusBadRequest {
+		t.Errorf("matching error redirect = %d, want 400", status)
+	}
+	if result := <-results; result.err == nil {
+		t.Error("an error redirect with the matching state did not fail the login")
+	}
+}
@@ -1,64 +1,240 @@
 package stockxgo
 
-import "net/http"
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
 
+// StockXClient is the StockX API client. Every method has a Context variant
+// that carries the context through to the underlying HTTP request, so calls
+// can be cancelled or given a deadline.
 type StockXClient interface {
 	GetOrder(orderNumber string) (GetSingleOrderResponse, error)
+	GetOrderContext(ctx context.Context, orderNumber string) (GetSingleOrderResponse, error)
 	GetActiveOrders(options ...ActiveOrdersOption) (OrdersResponse, error)
+	GetActiveOrdersContext(ctx context.Context, options ...ActiveOrdersOption) (OrdersResponse, error)
+	ActiveOrdersIter(ctx context.Context, options ...ActiveOrdersOption) iter.Seq2[Order, error]
 	GetHistoricalOrders(options ...HistoricalOrdersOption) (OrdersResponse, error)
+	GetHistoricalOrdersContext(ctx context.Context, options ...HistoricalOrdersOption) (OrdersResponse, error)
+	HistoricalOrdersIter(ctx context.Context, options ...HistoricalOrdersOption) iter.Seq2[Order, error]
+	GetShippingLabel(orderNumber string) (ShippingDocument, error)
+	GetShippingLabelContext(ctx context.Context, orderNumber string) (ShippingDocument, error)
+	GetShippingDocument(orderNumber string) (ShippingDocument, error)
+	GetShippingDocumentContext(ctx context.Context, orderNumber string) (ShippingDocument, error)
+	SaveShippingLabels(ctx context.Context, status OrderStatus, dir string) (SaveShippingLabelsResult, error)
 	Authenticate() error
+	AuthenticateContext(ctx context.Context) error
+	ExchangeCode(code, codeVerifier string) error
+	ExchangeCodeContext(ctx context.Context, code, codeVerifier string) error
+	AuthorizationURL(state string, opts ...AuthorizationURLOption) string
+	InteractiveLogin(ctx context.Context, opts ...InteractiveLoginOption) (Session, error)
 	RefreshToken() error
+	RefreshTokenContext(ctx context.Context) error
 	CreateListing(payload CreateLisingPayload) (ListingModificationResponse, error)
+	CreateListingContext(ctx context.Context, payload CreateLisingPayload) (ListingModificationResponse, error)
 	GetAllListings(options ...GetAllListingsOption) (GetAllListingsResponse, error)
+	GetAllListingsContext(ctx context.Context, options ...GetAllListingsOption) (GetAllListingsResponse, error)
+	ListingsIter(ctx context.Context, options ...GetAllListingsOption) iter.Seq2[Listing, error]
 	GetListing(listingID string) (GetListingResponse, error)
+	GetListingContext(ctx context.Context, listingID string) (GetListingResponse, error)
 	GetAllListingOperations(listingID string) (GetAllListingOperationsResponse, error)
+	GetAllListingOperationsContext(ctx context.Context, listingID string) (GetAllListingOperationsResponse, error)
 	GetListingOperation(listingID, operationID string) (GetListingOperationResponse, error)
+	GetListingOperationContext(ctx context.Context, listingID, operationID string) (GetListingOperationResponse, error)
+	WaitForOperation(ctx context.Context, listingID, operationID string) (GetListingOperationResponse, error)
+	CreateListingAndWait(ctx context.Context, payload CreateLisingPayload) (GetListingOperationResponse, error)
+	UpdateListingAndWait(ctx context.Context, listingID string, payload UpdateListingPayload) (GetListingOperationResponse, error)
 	ActivateListing(listingID string, payload ActivateListingPayload) (ListingModificationResponse, error)
+	ActivateListingContext(ctx context.Context, listingID string, payload ActivateListingPayload) (ListingModificationResponse, error)
 	DeactivateListing(listingID string) (ListingModificationResponse, error)
+	DeactivateListingContext(ctx context.Context, listingID string) (ListingModificationResponse, error)
 	UpdateListing(listingID string, payload UpdateListingPayload) (ListingModificationResponse, error)
+	UpdateListingContext(ctx context.Context, listingID string, payload UpdateListingPayload) (ListingModificationResponse, error)
 	DeleteListing(listingID string) (ListingModificationResponse, error)
+	DeleteListingContext(ctx context.Context, listingID string) (ListingModificationResponse, error)
+	CreateListingsBatch(items []BatchCreateListingItem) (BatchStatusResponse, error)
+	CreateListingsBatchContext(ctx context.Context, items []BatchCreateListingItem) (BatchStatusResponse, error)
+	UpdateListingsBatch(items []BatchUpdateListingItem) (BatchStatusResponse, error)
+	UpdateListingsBatchContext(ctx context.Context, items []BatchUpdateListingItem) (BatchStatusResponse, error)
+	ActivateListingsBatch(items []BatchUpdateListingItem) (BatchStatusResponse, error)
+	ActivateListingsBatchContext(ctx context.Context, items []BatchUpdateListingItem) (BatchStatusResponse, error)
+	DeactivateListingsBatch(listingIDs []string) (BatchStatusResponse, error)
+	DeactivateListingsBatchContext(ctx context.Context, listingIDs []string) (BatchStatusResponse, error)
+	DeleteListingsBatch(listingIDs []string) (BatchStatusResponse, error)
+	DeleteListingsBatchContext(ctx context.Context, listingIDs []string) (BatchStatusResponse, error)
+	GetBatchStatus(operation BatchOperation, batchID string) (BatchStatusResponse, error)
+	GetBatchStatusContext(ctx context.Context, operation BatchOperation, batchID string) (BatchStatusResponse, error)
+	GetBatchItems(operation BatchOperation, batchID string, statuses ...string) (BatchItemsResponse, error)
+	GetBatchItemsContext(ctx context.Context, operation BatchOperation, batchID string, statuses ...string) (BatchItemsResponse, error)
+	GetBatchResult(operation BatchOperation, batchID string) (BatchResult, error)
+	GetBatchResultContext(ctx context.Context, operation BatchOperation, batchID string) (BatchResult, error)
 	SearchCatalog(opts ...SearchCatalogOption) (SearchCatalogResponse, error)
+	SearchCatalogContext(ctx context.Context, opts ...SearchCatalogOption) (SearchCatalogResponse, error)
+	SearchCatalogIter(ctx context.Context, opts ...SearchCatalogOption) iter.Seq2[Product, error]
 	GetSingleProduct(productID string) (Product, error)
+	GetSingleProductContext(ctx context.Context, productID string) (Product, error)
 	GetAllProductVariants(productID string) ([]ProductVariant, error)
+	GetAllProductVariantsContext(ctx context.Context, productID string) ([]ProductVariant, error)
 	GetSingleProductVariant(productID, variantID string) (ProductVariant, error)
+	GetSingleProductVariantContext(ctx context.Context, productID, variantID string) (ProductVariant, error)
 	GetProductMarketData(productID, currencyCode string) ([]MarketData, error)
+	GetProductMarketDataContext(ctx context.Context, productID, currencyCode string) ([]MarketData, error)
 	GetProductMarketDataForVariant(productID, variantID, currencyCode string) (MarketData, error)
+	GetProductMarketDataForVariantContext(ctx context.Context, productID, variantID, currencyCode string) (MarketData, error)
+	GetBulkMarketData(ctx context.Context, productIDs []string, opts ...BulkMarketDataOption) (BulkMarketDataResult, error)
 	GetAccessToken() string
 	GetRefreshToken() string
 	GetExpiresIn() int
+	// GetSession returns a copy of the current session.
+	GetSession() Session
+	// Close stops the background token refresh. Requests made after Close
+	// fail with ErrClientClosed.
+	Close() error
+	// RateLimitState returns the current state of every rate limit bucket.
+	RateLimitState() map[EndpointFamily]BucketState
 }
 
 type stockXClient struct {
-	client       *http.Client
-	code         string
-	clientID     string
-	clientSecret string
-	session      Session
-	apiKey       string
+	client          *http.Client
+	code            string
+	clientID        string
+	clientSecret    string
+	sessionMu       sync.RWMutex
+	session         Session
+	refreshMu       sync.Mutex
+	refreshCall     *refreshCall
+	authMu          sync.Mutex
+	loopMu          sync.Mutex
+	loopRunning     bool
+	loopWG          sync.WaitGroup
+	lifecycle       context.Context
+	stop            context.CancelFunc
+	onRefreshError  func(error)
+	tokenStore      TokenStore
+	snapshotStore   SnapshotStore
+	onSnapshotError func(error)
+	redirectURI     string
+	codeVerifier    string
+	apiKey          string
+	retryPolicy     RetryPolicy
+	limiter         *rateLimiter
+	baseURL         string
+	authBaseURL     string
+	userAgent       string
+	defaultCurrency string
 }
 
 type Session struct {
-	AccessToken  string
-	RefreshToken string
-	ExpiresIn    int
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+	// ExpiresAt is when AccessToken expires. It is zero when unknown, for
+	// example for a session built by hand from stored tokens. When only
+	// ExpiresIn is set, the client derives it counting from when the session
+	// is adopted.
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
-func NewClient(code, clientID, clientSecret, apiKey string) StockXClient {
-	return &stockXClient{
-		code:         code,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		apiKey:       apiKey,
-		client:       &http.Client{},
+// New creates a client configured by the given options.
+func New(opts ...ClientOption) StockXClient {
+	cfg := clientConfig{
+		retryPolicy: DefaultRetryPolicy(),
+		redirectURI: DefaultRedirectURI,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &stockXClient{
+		client:          cfg.buildHTTPClient(),
+		code:            cfg.code,
+		clientID:        cfg.clientID,
+		clientSecret:    cfg.clientSecret,
+		session:         cfg.session.withExpiry(),
+		apiKey:          cfg.apiKey,
+		retryPolicy:     cfg.retryPolicy,
+		baseURL:         strings.TrimSuffix(cfg.baseURL, "/"),
+		authBaseURL:     strings.TrimSuffix(cfg.authBaseURL, "/"),
+		userAgent:       cfg.userAgent,
+		defaultCurrency: cfg.defaultCurrency,
+		onRefreshError:  cfg.onRefreshError,
+		tokenStore:      cfg.tokenStore,
+		snapshotStore:   cfg.snapshotStore,
+		onSnapshotError: cfg.onSnapshotError,
+		redirectURI:     cfg.redirectURI,
+		codeVerifier:    cfg.codeVerifier,
+	}
+
+	s.lifecycle, s.stop = context.WithCancel(context.Background())
+
+	if cfg.rateLimits != nil {
+		s.limiter = newRateLimiter(cfg.rateLimits)
 	}
+
+	return s
+}
+
+func NewClient(code, clientID, clientSecret, apiKey string) StockXClient {
+	return New(
+		WithCode(code),
+		WithCredentials(clientID, clientSecret),
+		WithAPIKey(apiKey),
+	)
 }
 
 func NewClientWithSession(session Session, clientID, clientSecret, apiKey string) StockXClient {
-	return &stockXClient{
-		session:      session,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		apiKey:       apiKey,
-		client:       &http.Client{},
+	return New(
+		WithSession(session),
+		WithCredentials(clientID, clientSecret),
+		WithAPIKey(apiKey),
+	)
+}
+
+// endpoint formats one of the package endpoint templates and points it at
+// the client's base URL when one was configured.
+func (s *stockXClient) endpoint(format string, args ...any) string {
+	u := fmt.Sprintf(format, args...)
+
+	if s.baseURL != "" && strings.HasPrefix(u, DefaultBaseURL) {
+		return s.baseURL + strings.TrimPrefix(u, DefaultBaseURL)
+	}
+
+	if s.authBaseURL != "" && strings.HasPrefix(u, DefaultAuthBaseURL) {
+		return s.authBaseURL + strings.TrimPrefix(u, DefaultAuthBaseURL)
+	}
+
+	return u
+}
+
+// currency returns code, falling back to the client's default currency.
+func (s *stockXClient) currency(code string) string {
+	if code == "" {
+		return s.defaultCurrency
 	}
+	return code
+}
+
+func (s *stockXClient) RateLimitState() map[EndpointFamily]BucketState {
+	return s.limiter.state()
+}
+
+func (s *stockXClient) Close() error {
+	s.stop()
+
+	// Goroutines are only added to loopWG under these locks and while the
+	// client is open, so once both have been taken nothing new can start
+	// and Wait covers everything still running.
+	s.loopMu.Lock()
+	s.loopMu.Unlock()
+	s.refreshMu.Lock()
+	s.refreshMu.Unlock()
+
+	s.loopWG.Wait()
+	return nil
 }
@@ -0,0 +1,205 @@
+package stockxgo
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultBaseURL     = "https://api.stockx.com"
+	DefaultAuthBaseURL = "https://accounts.stockx.com"
+)
+
+type clientConfig struct {
+	code            string
+	clientID        string
+	clientSecret    string
+	apiKey          string
+	session         Session
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	timeout         time.Duration
+	baseURL         string
+	authBaseURL     string
+	userAgent       string
+	defaultCurrency string
+	retryPolicy     RetryPolicy
+	rateLimits      RateLimits
+	onRefreshError  func(error)
+	tokenStore      TokenStore
+	snapshotStore   SnapshotStore
+	onSnapshotError func(error)
+	redirectURI     string
+	codeVerifier    string
+}
+
+// buildHTTPClient returns the HTTP client described by the config. A client
+// passed with WithHTTPClient is copied rather than modified when a transport
+// or timeout is also set.
+func (c clientConfig) buildHTTPClient() *http.Client {
+	if c.httpClient == nil && c.transport == nil && c.timeout == 0 {
+		return &http.Client{}
+	}
+
+	client := &http.Client{}
+	if c.httpClient != nil {
+		copied := *c.httpClient
+		client = &copied
+	}
+
+	if c.transport != nil {
+		client.Transport = c.transport
+	}
+
+	if c.timeout > 0 {
+		client.Timeout = c.timeout
+	}
+
+	return client
+}
+
+type ClientOption func(*clientConfig)
+
+// WithCode sets the authorization code exchanged by Authenticate.
+func WithCode(code string) ClientOption {
+	return func(c *clientConfig) {
+		c.code = code
+	}
+}
+
+// WithCodeVerifier sets the PKCE code verifier sent along with the code
+// exchanged by Authenticate.
+func WithCodeVerifier(codeVerifier string) ClientOption {
+	return func(c *clientConfig) {
+		c.codeVerifier = codeVerifier
+	}
+}
+
+// WithRedirectURI sets the redirect URI used in the authorize URL and the
+// code exchange. Defaults to DefaultRedirectURI.
+func WithRedirectURI(redirectURI string) ClientOption {
+	return func(c *clientConfig) {
+		c.redirectURI = redirectURI
+	}
+}
+
+// WithCredentials sets the OAuth client ID and secret.
+func WithCredentials(clientID, clientSecret string) ClientOption {
+	return func(c *clientConfig) {
+		c.clientID = clientID
+		c.clientSecret = clientSecret
+	}
+}
+
+// WithAPIKey sets the key sent in the x-api-key header.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *clientConfig) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithSession starts the client with an existing session instead of an
+// authorization code.
+func WithSession(session Session) ClientOption {
+	return func(c *clientConfig) {
+		c.session = session
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for every request.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithTransport sets the RoundTripper used for every request.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *clientConfig) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout of each HTTP request.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithBaseURL points API requests at baseURL instead of DefaultBaseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *clientConfig) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithAuthBaseURL points OAuth requests at authBaseURL instead of
+// DefaultAuthBaseURL.
+func WithAuthBaseURL(authBaseURL string) ClientOption {
+	return func(c *clientConfig) {
+		c.authBaseURL = authBaseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithDefaultCurrency sets the currency used when a listing payload or a
+// market data call does not specify one.
+func WithDefaultCurrency(currencyCode string) ClientOption {
+	return func(c *clientConfig) {
+		c.defaultCurrency = currencyCode
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimits throttles outgoing requests with one token bucket per
+// endpoint family.
+func WithRateLimits(limits RateLimits) ClientOption {
+	return func(c *clientConfig) {
+		c.rateLimits = limits
+	}
+}
+
+// WithRefreshErrorHandler sets a callback invoked whenever the background
+// token refresh fails, so a dead session can be alerted on.
+func WithRefreshErrorHandler(handler func(error)) ClientOption {
+	return func(c *clientConfig) {
+		c.onRefreshError = handler
+	}
+}
+
+// WithTokenStore persists the session in store after every authentication and
+// token refresh, and loads it from there in Authenticate.
+func WithTokenStore(store TokenStore) ClientOption {
+	return func(c *clientConfig) {
+		c.tokenStore = store
+	}
+}
+
+// WithSnapshotStore records every market data response in store. A snapshot
+// that cannot be saved never fails the request; see WithSnapshotErrorHandler.
+func WithSnapshotStore(store SnapshotStore) ClientOption {
+	return func(c *clientConfig) {
+		c.snapshotStore = store
+	}
+}
+
+// WithSnapshotErrorHandler sets a callback invoked when market data cannot be
+// recorded in the snapshot store, so gaps in the history can be alerted on.
+func WithSnapshotErrorHandler(handler func(error)) ClientOption {
+	return func(c *clientConfig) {
+		c.onSnapshotError = handler
+	}
+}
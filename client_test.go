@@ -0,0 +1,53 @@
+package stockxgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+func TestCloseCancelsInFlightRefresh(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.Inject(stockxtest.Failure{Method: "POST", Path: "/oauth/token", Delay: 10 * time.Second})
+	client := srv.Client()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.RefreshToken()
+	}()
+
+	// Wait for the refresh to reach the server.
+	for len(srv.RequestsTo("POST", "/oauth/token")) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		client.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return while a refresh was in flight")
+	}
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("RefreshToken succeeded after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("RefreshToken did not return after Close")
+	}
+
+	if err := client.RefreshToken(); !errors.Is(err, stockxgo.ErrClientClosed) {
+		t.Errorf("RefreshToken after Close = %v, want ErrClientClosed", err)
+	}
+}
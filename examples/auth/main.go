@@ -12,6 +12,7 @@ func main() {
 	// client_secret - your client secret
 	// reference: https://developer.stockx.com/portal/authentication/
 	client := stockxgo.NewClient("code", "client_id", "client_secret", "api_key")
+	defer client.Close()
 
 	err := client.Authenticate()
 	if err != nil {
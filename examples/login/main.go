@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+func main() {
+	// the redirect uri must be registered for your application and point at
+	// a loopback address, the login helper listens on it for the callback
+	client := stockxgo.New(
+		stockxgo.WithCredentials("client_id", "client_secret"),
+		stockxgo.WithAPIKey("api_key"),
+		stockxgo.WithRedirectURI("http://localhost:3000/callback"),
+		stockxgo.WithTokenStore(stockxgo.NewFileTokenStore("stockx-session.json")),
+	)
+	defer client.Close()
+
+	session, err := client.InteractiveLogin(context.Background(), stockxgo.WithLoginOpenBrowser(true))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("logged in, access token expires at %s\n", session.ExpiresAt)
+}
@@ -9,6 +9,7 @@ import (
 
 func main() {
 	client := stockxgo.NewClient("code", "client_id", "client_secret", "api_key")
+	defer client.Close()
 
 	err := client.Authenticate()
 	if err != nil {
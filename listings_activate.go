@@ -2,8 +2,9 @@ package stockxgo
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -12,49 +13,44 @@ var (
 )
 
 type ActivateListingPayload struct {
-	Amount       string `json:"amount"`
+	Amount       Money  `json:"amount"`
 	CurrencyCode string `json:"currencyCode"`
 	ExpiresAt    string `json:"expiresAt"`
 }
 
-func NewActivateListingPayload(amount, currencyCode, expiresAt string) ActivateListingPayload {
+// NewActivateListingPayload builds a payload whose currency code is taken from amount.
+func NewActivateListingPayload(amount Money, expiresAt string) ActivateListingPayload {
 	return ActivateListingPayload{
 		Amount:       amount,
-		CurrencyCode: currencyCode,
+		CurrencyCode: amount.Currency(),
 		ExpiresAt:    expiresAt,
 	}
 }
 
 func (s *stockXClient) ActivateListing(listingID string, payload ActivateListingPayload) (ListingModificationResponse, error) {
+	return s.ActivateListingContext(context.Background(), listingID, payload)
+}
+
+func (s *stockXClient) ActivateListingContext(ctx context.Context, listingID string, payload ActivateListingPayload) (ListingModificationResponse, error) {
+	payload.CurrencyCode = s.currency(cmp.Or(payload.CurrencyCode, payload.Amount.Currency()))
+
 	payloadRaw, err := json.Marshal(payload)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf(ActivateListingEndpoint, listingID), bytes.NewBuffer(payloadRaw))
+	req, err := http.NewRequestWithContext(ctx, "PUT", s.endpoint(ActivateListingEndpoint, listingID), bytes.NewBuffer(payloadRaw))
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	var response ListingModificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	return response, nil
+	return decodeListingModification(resp, listingID)
 }
@@ -0,0 +1,32 @@
+package stockxgo
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"slices"
+)
+
+type BatchCreateListingItem struct {
+	Amount        Money  `json:"amount"`
+	VariantID     string `json:"variantId"`
+	CurrencyCode  string `json:"currencyCode,omitempty"`
+	ExpiresAt     string `json:"expiresAt,omitempty"`
+	Active        *bool  `json:"active,omitempty"`
+	InventoryType string `json:"inventoryType,omitempty"`
+}
+
+func (s *stockXClient) CreateListingsBatch(items []BatchCreateListingItem) (BatchStatusResponse, error) {
+	return s.CreateListingsBatchContext(context.Background(), items)
+}
+
+// CreateListingsBatchContext queues the creation of many listings at once.
+// Use GetBatchResult with BatchOperationCreateListing to follow it up.
+func (s *stockXClient) CreateListingsBatchContext(ctx context.Context, items []BatchCreateListingItem) (BatchStatusResponse, error) {
+	items = slices.Clone(items)
+	for i := range items {
+		items[i].CurrencyCode = s.currency(cmp.Or(items[i].CurrencyCode, items[i].Amount.Currency()))
+	}
+
+	return s.submitBatch(ctx, http.MethodPost, BatchOperationCreateListing, items)
+}
@@ -0,0 +1,25 @@
+package stockxgo
+
+import (
+	"context"
+	"net/http"
+)
+
+type BatchDeleteListingItem struct {
+	ListingID string `json:"listingId"`
+}
+
+func (s *stockXClient) DeleteListingsBatch(listingIDs []string) (BatchStatusResponse, error) {
+	return s.DeleteListingsBatchContext(context.Background(), listingIDs)
+}
+
+// DeleteListingsBatchContext queues the deletion of many listings at once.
+// Use GetBatchResult with BatchOperationDeleteListing to follow it up.
+func (s *stockXClient) DeleteListingsBatchContext(ctx context.Context, listingIDs []string) (BatchStatusResponse, error) {
+	items := make([]BatchDeleteListingItem, len(listingIDs))
+	for i, listingID := range listingIDs {
+		items[i] = BatchDeleteListingItem{ListingID: listingID}
+	}
+
+	return s.submitBatch(ctx, http.MethodPost, BatchOperationDeleteListing, items)
+}
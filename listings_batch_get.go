@@ -0,0 +1,202 @@
+package stockxgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	BatchEndpoint       = "https://api.stockx.com/v2/selling/batch/%v"
+	BatchStatusEndpoint = "https://api.stockx.com/v2/selling/batch/%v/%v"
+	BatchItemsEndpoint  = "https://api.stockx.com/v2/selling/batch/%v/%v/items"
+)
+
+// BatchOperation identifies one of the selling batch endpoints.
+type BatchOperation string
+
+const (
+	BatchOperationCreateListing BatchOperation = "create-listing"
+	BatchOperationUpdateListing BatchOperation = "update-listing"
+	BatchOperationDeleteListing BatchOperation = "delete-listing"
+)
+
+// Batch and batch item statuses reported by StockX.
+const (
+	BatchStatusQueued     = "QUEUED"
+	BatchStatusInProgress = "IN_PROGRESS"
+	BatchStatusCompleted  = "COMPLETED"
+
+	BatchItemStatusQueued    = "QUEUED"
+	BatchItemStatusCompleted = "COMPLETED"
+	BatchItemStatusFailed    = "FAILED"
+)
+
+type BatchStatusResponse struct {
+	BatchID      string `json:"batchId"`
+	Status       string `json:"status"`
+	TotalItems   int    `json:"totalItems"`
+	ItemStatuses struct {
+		Queued    int `json:"queued"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed"`
+	} `json:"itemStatuses"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// Done reports whether StockX finished processing every item of the batch.
+func (b BatchStatusResponse) Done() bool {
+	return b.Status == BatchStatusCompleted
+}
+
+type BatchItemsResponse struct {
+	Items []BatchItem `json:"items"`
+}
+
+type BatchItem struct {
+	ItemID string `json:"itemId"`
+	Status string `json:"status"`
+	// ListingInput echoes the item as it was submitted.
+	ListingInput json.RawMessage `json:"listingInput"`
+	Result       struct {
+		ListingID string `json:"listingId"`
+		AskID     string `json:"askId"`
+	} `json:"result"`
+	Error string `json:"error"`
+}
+
+// BatchResult splits the items of a batch by outcome.
+type BatchResult struct {
+	Batch     BatchStatusResponse
+	Succeeded []BatchItem
+	Failed    []BatchItem
+	Pending   []BatchItem
+}
+
+func (s *stockXClient) GetBatchStatus(operation BatchOperation, batchID string) (BatchStatusResponse, error) {
+	return s.GetBatchStatusContext(context.Background(), operation, batchID)
+}
+
+func (s *stockXClient) GetBatchStatusContext(ctx context.Context, operation BatchOperation, batchID string) (BatchStatusResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.endpoint(BatchStatusEndpoint, operation, batchID), nil)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var response BatchStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (s *stockXClient) GetBatchItems(operation BatchOperation, batchID string, statuses ...string) (BatchItemsResponse, error) {
+	return s.GetBatchItemsContext(context.Background(), operation, batchID, statuses...)
+}
+
+// GetBatchItemsContext returns the items of a batch, optionally filtered by
+// item status.
+func (s *stockXClient) GetBatchItemsContext(ctx context.Context, operation BatchOperation, batchID string, statuses ...string) (BatchItemsResponse, error) {
+	u := s.endpoint(BatchItemsEndpoint, operation, batchID)
+	if len(statuses) > 0 {
+		queryParams := url.Values{}
+		queryParams.Add("status", strings.Join(statuses, ","))
+		u += "?" + queryParams.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return BatchItemsResponse{}, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return BatchItemsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var response BatchItemsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return BatchItemsResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (s *stockXClient) GetBatchResult(operation BatchOperation, batchID string) (BatchResult, error) {
+	return s.GetBatchResultContext(context.Background(), operation, batchID)
+}
+
+// GetBatchResultContext fetches the status and items of a batch and sorts
+// the items into succeeded, failed and pending.
+func (s *stockXClient) GetBatchResultContext(ctx context.Context, operation BatchOperation, batchID string) (BatchResult, error) {
+	status, err := s.GetBatchStatusContext(ctx, operation, batchID)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	items, err := s.GetBatchItemsContext(ctx, operation, batchID)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	result := BatchResult{Batch: status}
+	for _, item := range items.Items {
+		switch item.Status {
+		case BatchItemStatusCompleted:
+			result.Succeeded = append(result.Succeeded, item)
+		case BatchItemStatusFailed:
+			result.Failed = append(result.Failed, item)
+		default:
+			result.Pending = append(result.Pending, item)
+		}
+	}
+
+	return result, nil
+}
+
+// submitBatch sends items to one of the batch endpoints.
+func (s *stockXClient) submitBatch(ctx context.Context, method string, operation BatchOperation, items any) (BatchStatusResponse, error) {
+	payloadRaw, err := json.Marshal(struct {
+		Items any `json:"items"`
+	}{items})
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint(BatchEndpoint, operation), bytes.NewBuffer(payloadRaw))
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	var response BatchStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return BatchStatusResponse{}, err
+	}
+
+	return response, nil
+}
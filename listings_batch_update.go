@@ -0,0 +1,65 @@
+package stockxgo
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"slices"
+)
+
+type BatchUpdateListingItem struct {
+	ListingID    string `json:"listingId"`
+	Amount       *Money `json:"amount,omitempty"`
+	CurrencyCode string `json:"currencyCode,omitempty"`
+	ExpiresAt    string `json:"expiresAt,omitempty"`
+	Active       *bool  `json:"active,omitempty"`
+}
+
+func (s *stockXClient) UpdateListingsBatch(items []BatchUpdateListingItem) (BatchStatusResponse, error) {
+	return s.UpdateListingsBatchContext(context.Background(), items)
+}
+
+// UpdateListingsBatchContext queues updates of many listings at once. Use
+// GetBatchResult with BatchOperationUpdateListing to follow it up.
+func (s *stockXClient) UpdateListingsBatchContext(ctx context.Context, items []BatchUpdateListingItem) (BatchStatusResponse, error) {
+	items = slices.Clone(items)
+	for i := range items {
+		if items[i].Amount != nil {
+			items[i].CurrencyCode = s.currency(cmp.Or(items[i].CurrencyCode, items[i].Amount.Currency()))
+		}
+	}
+
+	return s.submitBatch(ctx, http.MethodPut, BatchOperationUpdateListing, items)
+}
+
+func (s *stockXClient) ActivateListingsBatch(items []BatchUpdateListingItem) (BatchStatusResponse, error) {
+	return s.ActivateListingsBatchContext(context.Background(), items)
+}
+
+// ActivateListingsBatchContext activates many listings at once through the
+// update batch endpoint, applying the amount and expiry of each item.
+func (s *stockXClient) ActivateListingsBatchContext(ctx context.Context, items []BatchUpdateListingItem) (BatchStatusResponse, error) {
+	items = slices.Clone(items)
+	active := true
+	for i := range items {
+		items[i].Active = &active
+	}
+
+	return s.UpdateListingsBatchContext(ctx, items)
+}
+
+func (s *stockXClient) DeactivateListingsBatch(listingIDs []string) (BatchStatusResponse, error) {
+	return s.DeactivateListingsBatchContext(context.Background(), listingIDs)
+}
+
+// DeactivateListingsBatchContext deactivates many listings at once through
+// the update batch endpoint.
+func (s *stockXClient) DeactivateListingsBatchContext(ctx context.Context, listingIDs []string) (BatchStatusResponse, error) {
+	active := false
+	items := make([]BatchUpdateListingItem, len(listingIDs))
+	for i, listingID := range listingIDs {
+		items[i] = BatchUpdateListingItem{ListingID: listingID, Active: &active}
+	}
+
+	return s.UpdateListingsBatchContext(ctx, items)
+}
@@ -2,9 +2,12 @@ package stockxgo
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
-	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -13,7 +16,7 @@ var (
 )
 
 type CreateLisingPayload struct {
-	Amount       string    `json:"amount"`
+	Amount       Money     `json:"amount"`
 	VariantID    string    `json:"variantId"`
 	CurrencyCode string    `json:"currencyCode"`
 	ExpiresAt    time.Time `json:"expiresAt"`
@@ -22,10 +25,13 @@ type CreateLisingPayload struct {
 
 type CreateListingOption func(*CreateLisingPayload)
 
-func NewCreateListingPayload(amount, variantID string, opts ...CreateListingOption) CreateLisingPayload {
+// NewCreateListingPayload builds a payload whose currency code is taken from
+// amount unless WithCurrencyCode overrides it.
+func NewCreateListingPayload(amount Money, variantID string, opts ...CreateListingOption) CreateLisingPayload {
 	payload := CreateLisingPayload{
-		Amount:    amount,
-		VariantID: variantID,
+		Amount:       amount,
+		VariantID:    variantID,
+		CurrencyCode: amount.Currency(),
 	}
 
 	for _, opt := range opts {
@@ -54,63 +60,81 @@ func WithActive(active bool) CreateListingOption {
 }
 
 func (s *stockXClient) CreateListing(payload CreateLisingPayload) (ListingModificationResponse, error) {
+	return s.CreateListingContext(context.Background(), payload)
+}
+
+func (s *stockXClient) CreateListingContext(ctx context.Context, payload CreateLisingPayload) (ListingModificationResponse, error) {
+	payload.CurrencyCode = s.currency(cmp.Or(payload.CurrencyCode, payload.Amount.Currency()))
+
 	payloadRaw, err := json.Marshal(payload)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
-	req, err := http.NewRequest("POST", CreateListingEndpoint, bytes.NewBuffer(payloadRaw))
+	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint(CreateListingEndpoint), bytes.NewBuffer(payloadRaw))
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
+	return decodeListingModification(resp, "")
+}
+
+type ListingModificationResponse struct {
+	ListingID             string           `json:"listingId"`
+	OperationID           string           `json:"operationId"`
+	OperationType         OperationType    `json:"operationType"`
+	OperationStatus       OperationStatus  `json:"operationStatus"`
+	OperationURL          string           `json:"operationUrl"`
+	OperationInitiatedBy  string           `json:"operationInitiatedBy"`
+	OperationInitiatedVia string           `json:"operationInitiatedVia"`
+	CreatedAt             time.Time        `json:"createdAt"`
+	UpdatedAt             time.Time        `json:"updatedAt"`
+	Changes               OperationChanges `json:"changes"`
+	Error                 interface{}      `json:"error"`
+}
+
+// decodeListingModification reads the response of an asynchronous listing
+// mutation. StockX may answer with an empty body (204) or only point at the
+// queued operation through the Location header, in which case the response
+// is filled in from what is known about the request.
+func decodeListingModification(resp *http.Response, listingID string) (ListingModificationResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	var response ListingModificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return ListingModificationResponse{}, err
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &response); err != nil {
+			return ListingModificationResponse{}, err
+		}
 	}
 
-	return response, nil
-}
+	if response.ListingID == "" {
+		response.ListingID = listingID
+	}
 
-type ListingModificationResponse struct {
-	ListingID             string    `json:"listingId"`
-	OperationID           string    `json:"operationId"`
-	OperationType         string    `json:"operationType"`
-	OperationStatus       string    `json:"operationStatus"`
-	OperationURL          string    `json:"operationUrl"`
-	OperationInitiatedBy  string    `json:"operationInitiatedBy"`
-	OperationInitiatedVia string    `json:"operationInitiatedVia"`
-	CreatedAt             time.Time `json:"createdAt"`
-	UpdatedAt             time.Time `json:"updatedAt"`
-	Changes               struct {
-		Additions struct {
-			Active  bool `json:"active"`
-			AskData struct {
-				Amount    string    `json:"amount"`
-				Currency  string    `json:"currency"`
-				ExpiresAt time.Time `json:"expiresAt"`
-			} `json:"askData"`
-		} `json:"additions"`
-		Updates struct {
-			UpdatedAt time.Time `json:"updatedAt"`
-		} `json:"updates"`
-		Removals struct {
-		} `json:"removals"`
-	} `json:"changes"`
-	Error interface{} `json:"error"`
+	if response.OperationURL == "" {
+		response.OperationURL = resp.Header.Get("Location")
+	}
+
+	if response.OperationURL != "" {
+		listingPart, operationPart, ok := strings.Cut(response.OperationURL, "/operations/")
+		if ok && response.OperationID == "" {
+			response.OperationID = strings.Trim(operationPart, "/")
+		}
+		if ok && response.ListingID == "" {
+			response.ListingID = listingPart[strings.LastIndex(listingPart, "/")+1:]
+		}
+	}
+
+	return response, nil
 }
@@ -0,0 +1,61 @@
+package stockxgo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+func TestListingModificationWithoutBody(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /v2/selling/listings/listing-1/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /v2/selling/listings/listing-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://api.stockx.com/v2/selling/listings/listing-1/operations/operation-9")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("POST /v2/selling/listings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/v2/selling/listings/listing-2/operations/operation-10/")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := stockxgo.New(
+		stockxgo.WithBaseURL(srv.URL),
+		stockxgo.WithSession(stockxgo.Session{AccessToken: "access-token"}),
+	)
+	defer client.Close()
+
+	// 204: only the listing ID from the request is known.
+	resp, err := client.DeactivateListing("listing-1")
+	if err != nil {
+		t.Fatalf("DeactivateListing: %v", err)
+	}
+	if resp.ListingID != "listing-1" || resp.OperationID != "" || resp.OperationURL != "" {
+		t.Errorf("204 response = %+v, want only the listing ID", resp)
+	}
+
+	// 202 with a Location header: the operation is taken from it.
+	resp, err = client.DeleteListing("listing-1")
+	if err != nil {
+		t.Fatalf("DeleteListing: %v", err)
+	}
+	if resp.ListingID != "listing-1" || resp.OperationID != "operation-9" ||
+		resp.OperationURL != "https://api.stockx.com/v2/selling/listings/listing-1/operations/operation-9" {
+		t.Errorf("202 response = %+v", resp)
+	}
+
+	// A created listing's ID is only known from the Location header.
+	resp, err = client.CreateListing(stockxgo.CreateLisingPayload{VariantID: "variant-1", Amount: stockxgo.MustParseMoney("100", "USD")})
+	if err != nil {
+		t.Fatalf("CreateListing: %v", err)
+	}
+	if resp.ListingID != "listing-2" || resp.OperationID != "operation-10" {
+		t.Errorf("202 create response = %+v, want listing-2 and operation-10", resp)
+	}
+}
@@ -1,8 +1,7 @@
 package stockxgo
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -11,30 +10,22 @@ var (
 )
 
 func (s *stockXClient) DeactivateListing(listingID string) (ListingModificationResponse, error) {
-	req, err := http.NewRequest("PUT", fmt.Sprintf(DeactivateListingEndpoint, listingID), nil)
+	return s.DeactivateListingContext(context.Background(), listingID)
+}
+
+func (s *stockXClient) DeactivateListingContext(ctx context.Context, listingID string) (ListingModificationResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", s.endpoint(DeactivateListingEndpoint, listingID), nil)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	var response ListingModificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	return response, nil
+	return decodeListingModification(resp, listingID)
 }
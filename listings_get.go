@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -12,25 +12,22 @@ var (
 )
 
 func (s *stockXClient) GetListing(listingID string) (GetListingResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf(GetListingsEndpoint, listingID), nil)
+	return s.GetListingContext(context.Background(), listingID)
+}
+
+func (s *stockXClient) GetListingContext(ctx context.Context, listingID string) (GetListingResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.endpoint(GetListingsEndpoint, listingID), nil)
 	if err != nil {
 		return GetListingResponse{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return GetListingResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return GetListingResponse{}, err
-	}
-
 	var response GetListingResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return GetListingResponse{}, err
@@ -40,13 +37,13 @@ func (s *stockXClient) GetListing(listingID string) (GetListingResponse, error)
 }
 
 type GetListingResponse struct {
-	ListingID     string    `json:"listingId"`
-	Status        string    `json:"status"`
-	Amount        string    `json:"amount"`
-	CurrencyCode  string    `json:"currencyCode"`
-	InventoryType string    `json:"inventoryType"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ListingID     string        `json:"listingId"`
+	Status        ListingStatus `json:"status"`
+	Amount        Money         `json:"amount"`
+	CurrencyCode  string        `json:"currencyCode"`
+	InventoryType string        `json:"inventoryType"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
 	Batch         struct {
 		BatchID string `json:"batchId"`
 		TaskID  string `json:"taskId"`
@@ -62,59 +59,75 @@ type GetListingResponse struct {
 		OrderCreatedAt time.Time `json:"orderCreatedAt"`
 		OrderStatus    string    `json:"orderStatus"`
 	} `json:"order"`
-	Product struct {
-		ProductID   string `json:"productId"`
-		ProductName string `json:"productName"`
-		StyleID     string `json:"styleId"`
-	} `json:"product"`
-	Variant struct {
-		VariantID    string `json:"variantId"`
-		VariantName  string `json:"variantName"`
-		VariantValue string `json:"variantValue"`
-	} `json:"variant"`
-	AuthenticationDetails struct {
-		Status       string `json:"status"`
-		FailureNotes string `json:"failureNotes"`
-	} `json:"authenticationDetails"`
-	Payout struct {
-		TotalPayout      float64 `json:"totalPayout"`
-		SalePrice        int     `json:"salePrice"`
-		TotalAdjustments int     `json:"totalAdjustments"`
-		CurrencyCode     string  `json:"currencyCode"`
-		Adjustments      []struct {
-			AdjustmentType string  `json:"adjustmentType"`
-			Amount         float64 `json:"amount"`
-			Percentage     float64 `json:"percentage"`
-		} `json:"adjustments"`
-	} `json:"payout"`
-	LastOperation struct {
-		OperationID           string    `json:"operationId"`
-		OperationType         string    `json:"operationType"`
-		OperationStatus       string    `json:"operationStatus"`
-		OperationInitiatedBy  string    `json:"operationInitiatedBy"`
-		OperationInitiatedVia string    `json:"operationInitiatedVia"`
-		OperationCreatedAt    time.Time `json:"operationCreatedAt"`
-		OperationUpdatedAt    time.Time `json:"operationUpdatedAt"`
-		Changes               struct {
-			Additions struct {
-				Active  bool `json:"active"`
-				AskData struct {
-					Amount    string    `json:"amount"`
-					Currency  string    `json:"currency"`
-					ExpiresAt time.Time `json:"expiresAt"`
-				} `json:"askData"`
-			} `json:"additions"`
-			Updates struct {
-				UpdatedAt time.Time `json:"updatedAt"`
-			} `json:"updates"`
-			Removals struct {
-			} `json:"removals"`
-		} `json:"changes"`
-		Error string `json:"error"`
+	Product               ProductRef            `json:"product"`
+	Variant               VariantRef            `json:"variant"`
+	AuthenticationDetails AuthenticationDetails `json:"authenticationDetails"`
+	Payout                Payout                `json:"payout"`
+	LastOperation         struct {
+		OperationID           string           `json:"operationId"`
+		OperationType         OperationType    `json:"operationType"`
+		OperationStatus       OperationStatus  `json:"operationStatus"`
+		OperationInitiatedBy  string           `json:"operationInitiatedBy"`
+		OperationInitiatedVia string           `json:"operationInitiatedVia"`
+		OperationCreatedAt    time.Time        `json:"operationCreatedAt"`
+		OperationUpdatedAt    time.Time        `json:"operationUpdatedAt"`
+		Changes               OperationChanges `json:"changes"`
+		Error                 string           `json:"error"`
 	} `json:"lastOperation"`
-	InitiatedShipments struct {
-		Inbound struct {
-			DisplayID string `json:"displayId"`
-		} `json:"inbound"`
-	} `json:"initiatedShipments"`
+	InitiatedShipments InitiatedShipments `json:"initiatedShipments"`
+}
+
+// ToListing returns the listing as it appears in GetAllListings. The payout
+// and last operation are dropped.
+func (r GetListingResponse) ToListing() Listing {
+	return Listing{
+		ListingID:             r.ListingID,
+		Status:                r.Status,
+		Amount:                r.Amount,
+		CurrencyCode:          r.CurrencyCode,
+		InventoryType:         r.InventoryType,
+		CreatedAt:             r.CreatedAt,
+		UpdatedAt:             r.UpdatedAt,
+		Batch:                 r.Batch,
+		Ask:                   r.Ask,
+		AuthenticationDetails: r.AuthenticationDetails,
+		Order:                 r.Order,
+		Product:               r.Product,
+		InitiatedShipments:    r.InitiatedShipments,
+		Variant:               r.Variant,
+	}
+}
+
+// ToGetListingResponse returns the listing in the shape of GetListing. The
+// payout and last operation, which GetAllListings does not return, are
+// left empty.
+func (l Listing) ToGetListingResponse() GetListingResponse {
+	return GetListingResponse{
+		ListingID:             l.ListingID,
+		Status:                l.Status,
+		Amount:                l.Amount,
+		CurrencyCode:          l.CurrencyCode,
+		InventoryType:         l.InventoryType,
+		CreatedAt:             l.CreatedAt,
+		UpdatedAt:             l.UpdatedAt,
+		Batch:                 l.Batch,
+		Ask:                   l.Ask,
+		Order:                 l.Order,
+		Product:               l.Product,
+		Variant:               l.Variant,
+		AuthenticationDetails: l.AuthenticationDetails,
+		InitiatedShipments:    l.InitiatedShipments,
+	}
+}
+
+// UnmarshalJSON tags the listing amount with CurrencyCode.
+func (r *GetListingResponse) UnmarshalJSON(data []byte) error {
+	type getListingResponse GetListingResponse
+	if err := json.Unmarshal(data, (*getListingResponse)(r)); err != nil {
+		return err
+	}
+
+	r.Amount = r.Amount.WithCurrency(r.CurrencyCode)
+
+	return nil
 }
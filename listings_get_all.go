@@ -1,6 +1,7 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,6 +15,28 @@ var (
 	GetAllListingsEndpoint = "https://api.stockx.com/v2/selling/listings"
 )
 
+// ListingStatus represents possible listing statuses
+type ListingStatus string
+
+const (
+	ListingStatusInactive  ListingStatus = "INACTIVE"
+	ListingStatusActive    ListingStatus = "ACTIVE"
+	ListingStatusDeleted   ListingStatus = "DELETED"
+	ListingStatusCanceled  ListingStatus = "CANCELED"
+	ListingStatusMatched   ListingStatus = "MATCHED"
+	ListingStatusCompleted ListingStatus = "COMPLETED"
+)
+
+// Valid reports whether s is a listing status documented by StockX.
+func (s ListingStatus) Valid() bool {
+	switch s {
+	case ListingStatusInactive, ListingStatusActive, ListingStatusDeleted, ListingStatusCanceled, ListingStatusMatched, ListingStatusCompleted:
+		return true
+	}
+
+	return false
+}
+
 type GetAllListingsRequest struct {
 	pageNumber                  int
 	pageSize                    int
@@ -22,7 +45,7 @@ type GetAllListingsRequest struct {
 	batchIDs                    []string
 	fromDate                    time.Time
 	toDate                      time.Time
-	listingStatuses             []string
+	listingStatuses             []ListingStatus
 	inventoryTypes              []string
 	initiatedShipmentDisplayIds []string
 }
@@ -69,7 +92,9 @@ func WithGetAllListingsToDate(toDate time.Time) GetAllListingsOption {
 	}
 }
 
-func WithGetAllListingsListingStatuses(listingStatuses []string) GetAllListingsOption {
+// WithGetAllListingsListingStatuses filters listings by status. Unknown
+// statuses make the request fail with ErrInvalidFilter before it is sent.
+func WithGetAllListingsListingStatuses(listingStatuses []ListingStatus) GetAllListingsOption {
 	return func(r *GetAllListingsRequest) {
 		r.listingStatuses = listingStatuses
 	}
@@ -90,6 +115,10 @@ func WithGetAllListingsInitiatedShipmentDisplayIds(initiatedShipmentDisplayIds [
 type GetAllListingsOption func(*GetAllListingsRequest)
 
 func (s *stockXClient) GetAllListings(options ...GetAllListingsOption) (GetAllListingsResponse, error) {
+	return s.GetAllListingsContext(context.Background(), options...)
+}
+
+func (s *stockXClient) GetAllListingsContext(ctx context.Context, options ...GetAllListingsOption) (GetAllListingsResponse, error) {
 	request := &GetAllListingsRequest{
 		pageNumber: 1,
 		pageSize:   100,
@@ -99,6 +128,12 @@ func (s *stockXClient) GetAllListings(options ...GetAllListingsOption) (GetAllLi
 		option(request)
 	}
 
+	for _, status := range request.listingStatuses {
+		if !status.Valid() {
+			return GetAllListingsResponse{}, fmt.Errorf("%w: unknown listing status %q", ErrInvalidFilter, status)
+		}
+	}
+
 	queryParams := url.Values{}
 	queryParams.Add("pageNumber", strconv.Itoa(request.pageNumber))
 	queryParams.Add("pageSize", strconv.Itoa(request.pageSize))
@@ -124,7 +159,11 @@ func (s *stockXClient) GetAllListings(options ...GetAllListingsOption) (GetAllLi
 	}
 
 	if len(request.listingStatuses) > 0 {
-		queryParams.Add("listingStatuses", strings.Join(request.listingStatuses, ","))
+		statuses := make([]string, len(request.listingStatuses))
+		for i, status := range request.listingStatuses {
+			statuses[i] = string(status)
+		}
+		queryParams.Add("listingStatuses", strings.Join(statuses, ","))
 	}
 
 	if len(request.inventoryTypes) > 0 {
@@ -135,27 +174,20 @@ func (s *stockXClient) GetAllListings(options ...GetAllListingsOption) (GetAllLi
 		queryParams.Add("initiatedShipmentDisplayIds", strings.Join(request.initiatedShipmentDisplayIds, ","))
 	}
 
-	url := fmt.Sprintf("%s?%s", GetAllListingsEndpoint, queryParams.Encode())
+	url := fmt.Sprintf("%s?%s", s.endpoint(GetAllListingsEndpoint), queryParams.Encode())
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return GetAllListingsResponse{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return GetAllListingsResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return GetAllListingsResponse{}, err
-	}
-
 	var response GetAllListingsResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return GetAllListingsResponse{}, err
@@ -173,13 +205,13 @@ type GetAllListingsResponse struct {
 }
 
 type Listing struct {
-	ListingID     string    `json:"listingId"`
-	Status        string    `json:"status"`
-	Amount        string    `json:"amount"`
-	CurrencyCode  string    `json:"currencyCode"`
-	InventoryType string    `json:"inventoryType"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ListingID     string        `json:"listingId"`
+	Status        ListingStatus `json:"status"`
+	Amount        Money         `json:"amount"`
+	CurrencyCode  string        `json:"currencyCode"`
+	InventoryType string        `json:"inventoryType"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
 	Batch         struct {
 		BatchID string `json:"batchId"`
 		TaskID  string `json:"taskId"`
@@ -190,28 +222,25 @@ type Listing struct {
 		AskUpdatedAt time.Time `json:"askUpdatedAt"`
 		AskExpiresAt time.Time `json:"askExpiresAt"`
 	} `json:"ask"`
-	AuthenticationDetails struct {
-		Status       string `json:"status"`
-		FailureNotes string `json:"failureNotes"`
-	} `json:"authenticationDetails"`
-	Order struct {
+	AuthenticationDetails AuthenticationDetails `json:"authenticationDetails"`
+	Order                 struct {
 		OrderNumber    string    `json:"orderNumber"`
 		OrderCreatedAt time.Time `json:"orderCreatedAt"`
 		OrderStatus    string    `json:"orderStatus"`
 	} `json:"order"`
-	Product struct {
-		ProductID   string `json:"productId"`
-		ProductName string `json:"productName"`
-		StyleID     string `json:"styleId"`
-	} `json:"product"`
-	InitiatedShipments struct {
-		Inbound struct {
-			DisplayID string `json:"displayId"`
-		} `json:"inbound"`
-	} `json:"initiatedShipments"`
-	Variant struct {
-		VariantID    string `json:"variantId"`
-		VariantName  string `json:"variantName"`
-		VariantValue string `json:"variantValue"`
-	} `json:"variant"`
+	Product            ProductRef         `json:"product"`
+	InitiatedShipments InitiatedShipments `json:"initiatedShipments"`
+	Variant            VariantRef         `json:"variant"`
+}
+
+// UnmarshalJSON decodes a listing and tags its amount with CurrencyCode.
+func (l *Listing) UnmarshalJSON(data []byte) error {
+	type listing Listing
+	if err := json.Unmarshal(data, (*listing)(l)); err != nil {
+		return err
+	}
+
+	l.Amount = l.Amount.WithCurrency(l.CurrencyCode)
+
+	return nil
 }
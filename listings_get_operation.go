@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -11,26 +11,63 @@ var (
 	GetListingOperation = "https://api.stockx.com/v2/selling/listings/%v/operations/%v"
 )
 
+// OperationType represents the kind of change a listing operation applies
+type OperationType string
+
+const (
+	OperationTypeCreate     OperationType = "CREATE"
+	OperationTypeUpdate     OperationType = "UPDATE"
+	OperationTypeActivate   OperationType = "ACTIVATE"
+	OperationTypeDeactivate OperationType = "DEACTIVATE"
+	OperationTypeDelete     OperationType = "DELETE"
+)
+
+// Valid reports whether t is an operation type documented by StockX.
+func (t OperationType) Valid() bool {
+	switch t {
+	case OperationTypeCreate, OperationTypeUpdate, OperationTypeActivate, OperationTypeDeactivate, OperationTypeDelete:
+		return true
+	}
+
+	return false
+}
+
+// OperationStatus represents the progress of a listing operation
+type OperationStatus string
+
+const (
+	OperationStatusPending   OperationStatus = "PENDING"
+	OperationStatusSucceeded OperationStatus = "SUCCEEDED"
+	OperationStatusFailed    OperationStatus = "FAILED"
+)
+
+// Valid reports whether s is an operation status documented by StockX.
+func (s OperationStatus) Valid() bool {
+	switch s {
+	case OperationStatusPending, OperationStatusSucceeded, OperationStatusFailed:
+		return true
+	}
+
+	return false
+}
+
 func (s *stockXClient) GetListingOperation(listingID, operationID string) (GetListingOperationResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf(GetListingOperation, listingID, operationID), nil)
+	return s.GetListingOperationContext(context.Background(), listingID, operationID)
+}
+
+func (s *stockXClient) GetListingOperationContext(ctx context.Context, listingID, operationID string) (GetListingOperationResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.endpoint(GetListingOperation, listingID, operationID), nil)
 	if err != nil {
 		return GetListingOperationResponse{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return GetListingOperationResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return GetListingOperationResponse{}, err
-	}
-
 	var response GetListingOperationResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return GetListingOperationResponse{}, err
@@ -40,28 +77,14 @@ func (s *stockXClient) GetListingOperation(listingID, operationID string) (GetLi
 }
 
 type GetListingOperationResponse struct {
-	ListingID             string    `json:"listingId"`
-	OperationID           string    `json:"operationId"`
-	OperationType         string    `json:"operationType"`
-	OperationStatus       string    `json:"operationStatus"`
-	OperationInitiatedBy  string    `json:"operationInitiatedBy"`
-	OperationInitiatedVia string    `json:"operationInitiatedVia"`
-	CreatedAt             time.Time `json:"createdAt"`
-	UpdatedAt             time.Time `json:"updatedAt"`
-	Changes               struct {
-		Additions struct {
-			Active  bool `json:"active"`
-			AskData struct {
-				Amount    string    `json:"amount"`
-				Currency  string    `json:"currency"`
-				ExpiresAt time.Time `json:"expiresAt"`
-			} `json:"askData"`
-		} `json:"additions"`
-		Updates struct {
-			UpdatedAt time.Time `json:"updatedAt"`
-		} `json:"updates"`
-		Removals struct {
-		} `json:"removals"`
-	} `json:"changes"`
-	Error interface{} `json:"error"`
+	ListingID             string           `json:"listingId"`
+	OperationID           string           `json:"operationId"`
+	OperationType         OperationType    `json:"operationType"`
+	OperationStatus       OperationStatus  `json:"operationStatus"`
+	OperationInitiatedBy  string           `json:"operationInitiatedBy"`
+	OperationInitiatedVia string           `json:"operationInitiatedVia"`
+	CreatedAt             time.Time        `json:"createdAt"`
+	UpdatedAt             time.Time        `json:"updatedAt"`
+	Changes               OperationChanges `json:"changes"`
+	Error                 interface{}      `json:"error"`
 }
@@ -2,8 +2,9 @@ package stockxgo
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -12,49 +13,44 @@ var (
 )
 
 type UpdateListingPayload struct {
-	Amount       string `json:"amount"`
+	Amount       Money  `json:"amount"`
 	CurrencyCode string `json:"currencyCode"`
 	ExpiresAt    string `json:"expiresAt"`
 }
 
-func NewUpdateListingPayload(amount, currencyCode, expiresAt string) UpdateListingPayload {
+// NewUpdateListingPayload builds a payload whose currency code is taken from amount.
+func NewUpdateListingPayload(amount Money, expiresAt string) UpdateListingPayload {
 	return UpdateListingPayload{
 		Amount:       amount,
-		CurrencyCode: currencyCode,
+		CurrencyCode: amount.Currency(),
 		ExpiresAt:    expiresAt,
 	}
 }
 
 func (s *stockXClient) UpdateListing(listingID string, payload UpdateListingPayload) (ListingModificationResponse, error) {
+	return s.UpdateListingContext(context.Background(), listingID, payload)
+}
+
+func (s *stockXClient) UpdateListingContext(ctx context.Context, listingID string, payload UpdateListingPayload) (ListingModificationResponse, error) {
+	payload.CurrencyCode = s.currency(cmp.Or(payload.CurrencyCode, payload.Amount.Currency()))
+
 	payloadRaw, err := json.Marshal(payload)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf(UpdateListingEndpoint, listingID), bytes.NewBuffer(payloadRaw))
+	req, err := http.NewRequestWithContext(ctx, "PATCH", s.endpoint(UpdateListingEndpoint, listingID), bytes.NewBuffer(payloadRaw))
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return ListingModificationResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	var response ListingModificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return ListingModificationResponse{}, err
-	}
-
-	return response, nil
+	return decodeListingModification(resp, listingID)
 }
@@ -0,0 +1,123 @@
+package stockxgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	operationPollInitialInterval = 500 * time.Millisecond
+	operationPollMaxInterval     = 10 * time.Second
+)
+
+var (
+	ErrOperationFailed = errors.New("listing operation failed")
+	ErrNoOperation     = errors.New("response does not reference a listing operation")
+	// ErrUnknownOperationStatus is returned when StockX reports a status
+	// that is not documented, including an empty one.
+	ErrUnknownOperationStatus = errors.New("unknown listing operation status")
+)
+
+// OperationError is returned when a listing operation ends in a failed
+// state. It matches ErrOperationFailed with errors.Is.
+type OperationError struct {
+	ListingID       string
+	OperationID     string
+	OperationType   OperationType
+	OperationStatus OperationStatus
+	// Detail is the error reported by StockX for the operation.
+	Detail interface{}
+}
+
+func (e *OperationError) Error() string {
+	msg := fmt.Sprintf("stockx: %s operation %s on listing %s ended as %s", e.OperationType, e.OperationID, e.ListingID, e.OperationStatus)
+
+	switch detail := e.Detail.(type) {
+	case nil:
+	case string:
+		if detail != "" {
+			msg += ": " + detail
+		}
+	default:
+		if raw, err := json.Marshal(detail); err == nil {
+			msg += ": " + string(raw)
+		}
+	}
+
+	return msg
+}
+
+func (e *OperationError) Is(target error) bool {
+	return target == ErrOperationFailed
+}
+
+// WaitForOperation polls a listing operation with backoff until it leaves
+// the PENDING state. It only succeeds once the operation has SUCCEEDED; a
+// failed operation is returned together with an *OperationError and an
+// unknown status with ErrUnknownOperationStatus.
+func (s *stockXClient) WaitForOperation(ctx context.Context, listingID, operationID string) (GetListingOperationResponse, error) {
+	interval := operationPollInitialInterval
+
+	for {
+		operation, err := s.GetListingOperationContext(ctx, listingID, operationID)
+		if err != nil {
+			return GetListingOperationResponse{}, err
+		}
+
+		if !operation.OperationStatus.Valid() {
+			return operation, fmt.Errorf("%w %q for operation %s", ErrUnknownOperationStatus, operation.OperationStatus, operationID)
+		}
+
+		switch operation.OperationStatus {
+		case OperationStatusSucceeded:
+			return operation, nil
+		case OperationStatusFailed:
+			return operation, &OperationError{
+				ListingID:       operation.ListingID,
+				OperationID:     operation.OperationID,
+				OperationType:   operation.OperationType,
+				OperationStatus: operation.OperationStatus,
+				Detail:          operation.Error,
+			}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return GetListingOperationResponse{}, err
+		}
+
+		interval = min(interval*2, operationPollMaxInterval)
+	}
+}
+
+// CreateListingAndWait creates a listing and blocks until StockX has applied
+// the creation.
+func (s *stockXClient) CreateListingAndWait(ctx context.Context, payload CreateLisingPayload) (GetListingOperationResponse, error) {
+	response, err := s.CreateListingContext(ctx, payload)
+	if err != nil {
+		return GetListingOperationResponse{}, err
+	}
+
+	return s.waitForModification(ctx, response)
+}
+
+// UpdateListingAndWait updates a listing and blocks until StockX has applied
+// the update.
+func (s *stockXClient) UpdateListingAndWait(ctx context.Context, listingID string, payload UpdateListingPayload) (GetListingOperationResponse, error) {
+	response, err := s.UpdateListingContext(ctx, listingID, payload)
+	if err != nil {
+		return GetListingOperationResponse{}, err
+	}
+
+	return s.waitForModification(ctx, response)
+}
+
+func (s *stockXClient) waitForModification(ctx context.Context, response ListingModificationResponse) (GetListingOperationResponse, error) {
+	if response.ListingID == "" || response.OperationID == "" {
+		return GetListingOperationResponse{}, ErrNoOperation
+	}
+
+	return s.WaitForOperation(ctx, response.ListingID, response.OperationID)
+}
@@ -0,0 +1,125 @@
+package stockxgo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+// queueOperation starts an asynchronous operation on a new listing and
+// returns the listing and operation IDs.
+func queueOperation(t *testing.T, srv *stockxtest.Server, client stockxgo.StockXClient) (string, string) {
+	t.Helper()
+
+	listing := srv.AddListing(stockxgo.Listing{Status: stockxgo.ListingStatusActive})
+	resp, err := client.DeactivateListing(listing.ListingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OperationStatus != stockxgo.OperationStatusPending {
+		t.Fatalf("queued operation is %s, want PENDING", resp.OperationStatus)
+	}
+
+	return listing.ListingID, resp.OperationID
+}
+
+func TestWaitForOperationPolls(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AsyncOperations = true
+	client := srv.Client()
+	defer client.Close()
+
+	listingID, operationID := queueOperation(t, srv, client)
+	path := fmt.Sprintf("/v2/selling/listings/%s/operations/%s", listingID, operationID)
+
+	// Report PENDING twice before the fake's own PENDING, so the interval
+	// doubles between polls: 500ms, 1s, 2s.
+	srv.Inject(stockxtest.Failure{
+		Method: "GET",
+		Path:   path,
+		Status: http.StatusOK,
+		Body:   fmt.Sprintf(`{"listingId":%q,"operationId":%q,"operationStatus":"PENDING"}`, listingID, operationID),
+		Times:  2,
+	})
+
+	start := time.Now()
+	operation, err := client.WaitForOperation(context.Background(), listingID, operationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.OperationStatus != stockxgo.OperationStatusSucceeded {
+		t.Errorf("operation status = %s, want SUCCEEDED", operation.OperationStatus)
+	}
+
+	srv.AssertRequestCount(t, "GET", path, 4)
+	if elapsed := time.Since(start); elapsed < 3500*time.Millisecond {
+		t.Errorf("polling took %s, want at least 3.5s of backoff", elapsed)
+	}
+}
+
+func TestWaitForOperationFailed(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AsyncOperations = true
+	client := srv.Client()
+	defer client.Close()
+
+	listingID, operationID := queueOperation(t, srv, client)
+	srv.FailOperation(listingID, operationID, "listing is locked")
+
+	operation, err := client.WaitForOperation(context.Background(), listingID, operationID)
+	if !errors.Is(err, stockxgo.ErrOperationFailed) {
+		t.Fatalf("error = %v, want ErrOperationFailed", err)
+	}
+
+	var opErr *stockxgo.OperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("error = %T, want *OperationError", err)
+	}
+	if opErr.ListingID != listingID || opErr.OperationID != operationID || opErr.OperationType != stockxgo.OperationTypeDeactivate ||
+		opErr.OperationStatus != stockxgo.OperationStatusFailed || opErr.Detail != "listing is locked" {
+		t.Errorf("OperationError = %+v", opErr)
+	}
+	if operation.OperationStatus != stockxgo.OperationStatusFailed {
+		t.Errorf("operation status = %s, want FAILED", operation.OperationStatus)
+	}
+}
+
+func TestWaitForOperationUnknownStatus(t *testing.T) {
+	for _, status := range []string{"", "CANCELLED"} {
+		t.Run(fmt.Sprintf("%q", status), func(t *testing.T) {
+			srv := stockxtest.NewServer()
+			defer srv.Close()
+
+			srv.AsyncOperations = true
+			client := srv.Client()
+			defer client.Close()
+
+			listingID, operationID := queueOperation(t, srv, client)
+			path := fmt.Sprintf("/v2/selling/listings/%s/operations/%s", listingID, operationID)
+			srv.Inject(stockxtest.Failure{
+				Method: "GET",
+				Path:   path,
+				Status: http.StatusOK,
+				Body:   fmt.Sprintf(`{"listingId":%q,"operationId":%q,"operationStatus":%q}`, listingID, operationID, status),
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if _, err := client.WaitForOperation(ctx, listingID, operationID); !errors.Is(err, stockxgo.ErrUnknownOperationStatus) {
+				t.Errorf("error = %v, want ErrUnknownOperationStatus", err)
+			}
+			srv.AssertRequestCount(t, "GET", path, 1)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package stockxgo
+
+import (
+	"cmp"
+	"context"
+	"sync"
+)
+
+const defaultBulkConcurrency = 4
+
+// MarketDataKey identifies the market data of a variant in a currency.
+type MarketDataKey struct {
+	ProductID    string
+	VariantID    string
+	CurrencyCode string
+}
+
+// Key returns the key of d.
+func (d MarketData) Key() MarketDataKey {
+	return MarketDataKey{d.ProductID, d.VariantID, d.CurrencyCode}
+}
+
+// BulkMarketDataResult holds the market data fetched by GetBulkMarketData.
+type BulkMarketDataResult struct {
+	Data map[MarketDataKey]MarketData
+	// Errors holds the error of every request that failed. When the variants
+	// of a product could not be listed, the error is keyed by the product ID
+	// alone.
+	Errors map[MarketDataKey]error
+}
+
+type BulkMarketDataOption func(*BulkMarketDataRequest)
+
+// BulkMarketDataRequest holds the parameters of GetBulkMarketData
+type BulkMarketDataRequest struct {
+	Concurrency int
+	Currencies  []string
+}
+
+// WithBulkConcurrency sets how many requests run at the same time
+// Defaults to 4
+func WithBulkConcurrency(concurrency int) BulkMarketDataOption {
+	return func(r *BulkMarketDataRequest) {
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		r.Concurrency = concurrency
+	}
+}
+
+// WithBulkCurrencies sets the currencies to fetch market data in
+// Defaults to the client's default currency
+func WithBulkCurrencies(currencies ...string) BulkMarketDataOption {
+	return func(r *BulkMarketDataRequest) {
+		r.Currencies = currencies
+	}
+}
+
+// GetBulkMarketData fetches the market data of every variant of the given
+// products in every requested currency. Duplicate products and currencies
+// are fetched once. Failed requests are reported per item in the result and
+// do not stop the others; the returned error is only set when ctx ends.
+func (s *stockXClient) GetBulkMarketData(ctx context.Context, productIDs []string, opts ...BulkMarketDataOption) (BulkMarketDataResult, error) {
+	request := &BulkMarketDataRequest{Concurrency: defaultBulkConcurrency}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	currencies := unique(request.Currencies)
+	if len(currencies) == 0 {
+		currencies = []string{s.currency("")}
+	}
+
+	result := BulkMarketDataResult{
+		Data:   make(map[MarketDataKey]MarketData),
+		Errors: make(map[MarketDataKey]error),
+	}
+	var mu sync.Mutex
+
+	var keys []MarketDataKey
+	forEachLimit(ctx, request.Concurrency, unique(productIDs), func(productID string) {
+		variants, err := s.GetAllProductVariantsContext(ctx, productID)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			result.Errors[MarketDataKey{ProductID: productID}] = err
+			return
+		}
+
+		for _, variant := range unique(variantIDs(variants)) {
+			for _, currency := range currencies {
+				keys = append(keys, MarketDataKey{productID, variant, currency})
+			}
+		}
+	})
+
+	forEachLimit(ctx, request.Concurrency, keys, func(key MarketDataKey) {
+		data, err := s.GetProductMarketDataForVariantContext(ctx, key.ProductID, key.VariantID, key.CurrencyCode)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			result.Errors[key] = err
+			return
+		}
+
+		// Without a requested currency StockX picks one; key the data by it.
+		key.CurrencyCode = cmp.Or(key.CurrencyCode, data.CurrencyCode)
+		result.Data[key] = data
+	})
+
+	return result, ctx.Err()
+}
+
+// forEachLimit calls fn for every item with at most limit calls running at
+// once. It stops starting new calls when ctx ends and waits for the running
+// ones to return.
+func forEachLimit[T any](ctx context.Context, limit int, items []T, fn func(T)) {
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(item)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func variantIDs(variants []ProductVariant) []string {
+	ids := make([]string, len(variants))
+	for i, variant := range variants {
+		ids[i] = variant.VariantID
+	}
+
+	return ids
+}
+
+// unique returns values without duplicates and empty strings, keeping the
+// order of first appearance.
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+
+	var result []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+
+	return result
+}
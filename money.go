@@ -0,0 +1,327 @@
+package stockxgo
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an exact decimal amount in an ISO 4217 currency. StockX sends
+// amounts as strings or numbers next to a separate currency code; Money
+// decodes both forms and always encodes the amount as a string.
+//
+// The zero value has no amount. It reads as zero in arithmetic and
+// comparisons and encodes as an empty string, the way StockX leaves amounts
+// out. A Money without a currency can be combined with any currency.
+type Money struct {
+	// units is the amount multiplied by 10^scale. It is never modified after
+	// construction, so copies of a Money can share it.
+	units    *big.Int
+	scale    int32
+	currency string
+}
+
+// NewMoney returns units * 10^-scale in the given currency, so
+// NewMoney(15099, 2, "USD") is 150.99 USD.
+func NewMoney(units int64, scale int32, currency string) Money {
+	return newMoney(big.NewInt(units), scale, currency)
+}
+
+// ParseMoney parses a decimal amount such as "150", "-12.50" or "1.5e2".
+func ParseMoney(amount, currency string) (Money, error) {
+	units, scale, err := parseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return newMoney(units, scale, currency), nil
+}
+
+// MustParseMoney is like ParseMoney but panics if the amount is invalid.
+func MustParseMoney(amount, currency string) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func newMoney(units *big.Int, scale int32, currency string) Money {
+	if scale < 0 {
+		units = new(big.Int).Mul(units, pow10(-scale))
+		scale = 0
+	}
+
+	return Money{units: units, scale: scale, currency: normaliseCurrency(currency)}
+}
+
+func normaliseCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns m in another currency without converting the amount.
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = normaliseCurrency(currency)
+	return m
+}
+
+// IsSet reports whether m holds an amount, even a zero one.
+func (m Money) IsSet() bool {
+	return m.units != nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+func (m Money) Sign() int {
+	if m.units == nil {
+		return 0
+	}
+
+	return m.units.Sign()
+}
+
+// String returns the amount with its original number of decimal places,
+// without the currency.
+func (m Money) String() string {
+	if m.units == nil {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(m.units).String()
+	if m.scale > 0 {
+		if pad := int(m.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(m.scale)] + "." + digits[len(digits)-int(m.scale):]
+	}
+
+	if m.units.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// Float64 returns the nearest float64 to the amount, for display and
+// statistics only.
+func (m Money) Float64() float64 {
+	f, _ := strconv.ParseFloat(m.String(), 64)
+	return f
+}
+
+func (m Money) Neg() Money {
+	return m.withUnits(new(big.Int).Neg(m.bigUnits()), m.scale)
+}
+
+func (m Money) Abs() Money {
+	return m.withUnits(new(big.Int).Abs(m.bigUnits()), m.scale)
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := commonCurrency(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.units == nil && other.units == nil {
+		return Money{currency: currency}, nil
+	}
+
+	a, b, scale := align(m, other)
+	return newMoney(new(big.Int).Add(a, b), scale, currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Mul multiplies the amount by an integer factor.
+func (m Money) Mul(factor int64) Money {
+	return m.withUnits(new(big.Int).Mul(m.bigUnits(), big.NewInt(factor)), m.scale)
+}
+
+// Div divides the amount by an integer and rounds the result half away from
+// zero to the given number of decimal places.
+func (m Money) Div(divisor int64, places int32) (Money, error) {
+	if divisor == 0 {
+		return Money{}, errors.New("stockx: money divided by zero")
+	}
+
+	num := m.bigUnits()
+	den := big.NewInt(divisor)
+	if places >= m.scale {
+		num = new(big.Int).Mul(num, pow10(places-m.scale))
+	} else {
+		den = new(big.Int).Mul(den, pow10(m.scale-places))
+	}
+
+	return m.withUnits(quoRound(num, den), places), nil
+}
+
+// Round rounds the amount half away from zero to the given number of decimal
+// places.
+func (m Money) Round(places int32) Money {
+	if places >= m.scale {
+		return m.withUnits(new(big.Int).Mul(m.bigUnits(), pow10(places-m.scale)), places)
+	}
+
+	return m.withUnits(quoRound(m.bigUnits(), pow10(m.scale-places)), places)
+}
+
+// Cmp compares two amounts and returns -1, 0 or +1. Amounts in different
+// currencies cannot be compared and return ErrCurrencyMismatch.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := commonCurrency(m, other); err != nil {
+		return 0, err
+	}
+
+	a, b, _ := align(m, other)
+	return a.Cmp(b), nil
+}
+
+// Equal reports whether two amounts are numerically equal and in compatible
+// currencies, so 150 USD equals 150.00 USD.
+func (m Money) Equal(other Money) bool {
+	c, err := m.Cmp(other)
+	return err == nil && c == 0
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.units == nil {
+		return []byte(`""`), nil
+	}
+
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts a string or a number. Null and the empty string
+// leave the amount unset. The currency of m is kept.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		m.units, m.scale = nil, 0
+		return nil
+	}
+
+	if strings.HasPrefix(raw, `"`) {
+		var err error
+		raw, err = strconv.Unquote(raw)
+		if err != nil {
+			return fmt.Errorf("stockx: invalid money amount %s: %w", data, err)
+		}
+		if strings.TrimSpace(raw) == "" {
+			m.units, m.scale = nil, 0
+			return nil
+		}
+	}
+
+	parsed, err := ParseMoney(raw, m.currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m Money) bigUnits() *big.Int {
+	if m.units == nil {
+		return new(big.Int)
+	}
+
+	return m.units
+}
+
+func (m Money) withUnits(units *big.Int, scale int32) Money {
+	return newMoney(units, scale, m.currency)
+}
+
+// align returns the units of a and b at their common scale.
+func align(a, b Money) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+
+	au := new(big.Int).Mul(a.bigUnits(), pow10(scale-a.scale))
+	bu := new(big.Int).Mul(b.bigUnits(), pow10(scale-b.scale))
+
+	return au, bu, scale
+}
+
+func commonCurrency(a, b Money) (string, error) {
+	switch {
+	case a.currency == "":
+		return b.currency, nil
+	case b.currency == "" || a.currency == b.currency:
+		return a.currency, nil
+	default:
+		return "", fmt.Errorf("stockx: %w: %s and %s", ErrCurrencyMismatch, a.currency, b.currency)
+	}
+}
+
+// quoRound divides num by den, rounding half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	if new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// parseDecimal parses an optionally signed decimal with an optional exponent
+// into units and a scale.
+func parseDecimal(s string) (*big.Int, int32, error) {
+	invalid := fmt.Errorf("stockx: invalid money amount %q", s)
+
+	s = strings.TrimSpace(s)
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		exponent, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return nil, 0, invalid
+		}
+	}
+
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	sign := ""
+	if strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		sign, whole = whole[:1], whole[1:]
+	}
+
+	digits := whole + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, 0, invalid
+	}
+
+	units, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return nil, 0, invalid
+	}
+
+	scale := int64(len(fraction)) - exponent
+	if scale > 1<<16 || scale < -(1<<16) {
+		return nil, 0, invalid
+	}
+
+	return units, int32(scale), nil
+}
@@ -0,0 +1,149 @@
+package stockxgo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"150", "150"},
+		{"-12.50", "-12.50"},
+		{"+7", "7"},
+		{" 3.10 ", "3.10"},
+		{".5", "0.5"},
+		{"0.05", "0.05"},
+		{"1.5e2", "150"},
+		{"1e-2", "0.01"},
+	}
+
+	for _, tt := range tests {
+		m, err := stockxgo.ParseMoney(tt.in, "usd")
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", tt.in, err)
+			continue
+		}
+		if got := m.String(); got != tt.want {
+			t.Errorf("ParseMoney(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if m.Currency() != "USD" {
+			t.Errorf("ParseMoney(%q) currency = %q, want USD", tt.in, m.Currency())
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3", "1e", "--1", "12,50"} {
+		if _, err := stockxgo.ParseMoney(in, "USD"); err == nil {
+			t.Errorf("ParseMoney(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestMoneyRound(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int32
+		want   string
+	}{
+		{"2.345", 2, "2.35"},
+		{"-2.345", 2, "-2.35"},
+		{"2.344", 2, "2.34"},
+		{"1.5", 0, "2"},
+		{"-1.5", 0, "-2"},
+		{"1.2", 2, "1.20"},
+	}
+
+	for _, tt := range tests {
+		if got := stockxgo.MustParseMoney(tt.in, "USD").Round(tt.places).String(); got != tt.want {
+			t.Errorf("%s.Round(%d) = %s, want %s", tt.in, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyDiv(t *testing.T) {
+	tests := []struct {
+		in      string
+		divisor int64
+		places  int32
+		want    string
+	}{
+		{"10", 3, 2, "3.33"},
+		{"20", 3, 2, "6.67"},
+		{"-10", 4, 0, "-3"},
+		{"1", 8, 2, "0.13"},
+		{"100.50", 2, 4, "50.2500"},
+	}
+
+	for _, tt := range tests {
+		got, err := stockxgo.MustParseMoney(tt.in, "USD").Div(tt.divisor, tt.places)
+		if err != nil {
+			t.Errorf("%s.Div(%d, %d): %v", tt.in, tt.divisor, tt.places, err)
+			continue
+		}
+		if got.String() != tt.want || got.Currency() != "USD" {
+			t.Errorf("%s.Div(%d, %d) = %s %s, want %s USD", tt.in, tt.divisor, tt.places, got, got.Currency(), tt.want)
+		}
+	}
+
+	if _, err := stockxgo.MustParseMoney("1", "USD").Div(0, 2); err == nil {
+		t.Error("Div(0) succeeded, want error")
+	}
+}
+
+func TestMoneyCurrencies(t *testing.T) {
+	usd := stockxgo.MustParseMoney("150", "USD")
+
+	sum, err := usd.Add(stockxgo.MustParseMoney("0.99", "USD"))
+	if err != nil || sum.String() != "150.99" {
+		t.Errorf("150 + 0.99 = %s, %v", sum, err)
+	}
+
+	if _, err := usd.Add(stockxgo.MustParseMoney("1", "EUR")); !errors.Is(err, stockxgo.ErrCurrencyMismatch) {
+		t.Errorf("USD + EUR error = %v, want ErrCurrencyMismatch", err)
+	}
+
+	// An amount without a currency combines with any currency.
+	sum, err = usd.Sub(stockxgo.MustParseMoney("10", ""))
+	if err != nil || sum.String() != "140" || sum.Currency() != "USD" {
+		t.Errorf("150 USD - 10 = %s %s, %v", sum, sum.Currency(), err)
+	}
+
+	if !usd.Equal(stockxgo.MustParseMoney("150.00", "USD")) {
+		t.Error("150 USD does not equal 150.00 USD")
+	}
+	if usd.Equal(stockxgo.MustParseMoney("150", "EUR")) {
+		t.Error("150 USD equals 150 EUR")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	for _, in := range []string{`"12.50"`, `12.50`} {
+		m := stockxgo.NewMoney(0, 0, "EUR")
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Fatalf("unmarshal %s: %v", in, err)
+		}
+		if m.String() != "12.50" || m.Currency() != "EUR" {
+			t.Errorf("unmarshal %s = %s %s, want 12.50 EUR", in, m, m.Currency())
+		}
+
+		out, err := json.Marshal(m)
+		if err != nil || string(out) != `"12.50"` {
+			t.Errorf("marshal = %s, %v", out, err)
+		}
+	}
+
+	for _, in := range []string{`null`, `""`} {
+		m := stockxgo.MustParseMoney("1", "USD")
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Fatalf("unmarshal %s: %v", in, err)
+		}
+		if m.IsSet() {
+			t.Errorf("unmarshal %s left the amount set to %s", in, m)
+		}
+	}
+}
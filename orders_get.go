@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetOrder(orderNumber string) (GetSingleOrderResponse, error) {
-	url := fmt.Sprintf(GetOrdersEndpoint, orderNumber)
+	return s.GetOrderContext(context.Background(), orderNumber)
+}
+
+func (s *stockXClient) GetOrderContext(ctx context.Context, orderNumber string) (GetSingleOrderResponse, error) {
+	url := s.endpoint(GetOrdersEndpoint, orderNumber)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return GetSingleOrderResponse{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return GetSingleOrderResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return GetSingleOrderResponse{}, err
-	}
-
 	var response GetSingleOrderResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return GetSingleOrderResponse{}, err
@@ -41,52 +38,95 @@ func (s *stockXClient) GetOrder(orderNumber string) (GetSingleOrderResponse, err
 	return response, nil
 }
 
+// AuthenticationStatus represents the state of StockX's authentication of an
+// item
+type AuthenticationStatus string
+
+const (
+	AuthenticationStatusPending AuthenticationStatus = "PENDING"
+	AuthenticationStatusPassed  AuthenticationStatus = "PASSED"
+	AuthenticationStatusFailed  AuthenticationStatus = "FAILED"
+)
+
+// Valid reports whether s is an authentication status documented by StockX.
+func (s AuthenticationStatus) Valid() bool {
+	switch s {
+	case AuthenticationStatusPending, AuthenticationStatusPassed, AuthenticationStatusFailed:
+		return true
+	}
+
+	return false
+}
+
 type GetSingleOrderResponse struct {
-	AskID        string    `json:"askId"`
-	OrderNumber  string    `json:"orderNumber"`
-	ListingID    string    `json:"listingId"`
-	Amount       string    `json:"amount"`
-	CurrencyCode string    `json:"currencyCode"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
-	Variant      struct {
-		VariantID    string `json:"variantId"`
-		VariantName  string `json:"variantName"`
-		VariantValue string `json:"variantValue"`
-	} `json:"variant"`
-	Product struct {
-		ProductID   string `json:"productId"`
-		ProductName string `json:"productName"`
-		StyleID     string `json:"styleId"`
-	} `json:"product"`
-	Status   string `json:"status"`
-	Shipment struct {
-		ShipByDate          string `json:"shipByDate"`
-		TrackingNumber      string `json:"trackingNumber"`
-		TrackingURL         string `json:"trackingUrl"`
-		CarrierCode         string `json:"carrierCode"`
-		ShippingLabelURL    string `json:"shippingLabelUrl"`
-		ShippingDocumentURL string `json:"shippingDocumentUrl"`
-	} `json:"shipment"`
-	InitiatedShipments struct {
-		Inbound struct {
-			DisplayID string `json:"displayId"`
-		} `json:"inbound"`
-	} `json:"initiatedShipments"`
-	InventoryType         string `json:"inventoryType"`
-	AuthenticationDetails struct {
-		Status       string `json:"status"`
-		FailureNotes string `json:"failureNotes"`
-	} `json:"authenticationDetails"`
-	Payout struct {
-		TotalPayout      float64 `json:"totalPayout"`
-		SalePrice        int     `json:"salePrice"`
-		TotalAdjustments int     `json:"totalAdjustments"`
-		CurrencyCode     string  `json:"currencyCode"`
-		Adjustments      []struct {
-			AdjustmentType string  `json:"adjustmentType"`
-			Amount         float64 `json:"amount"`
-			Percentage     float64 `json:"percentage"`
-		} `json:"adjustments"`
-	} `json:"payout"`
+	AskID                 string                `json:"askId"`
+	OrderNumber           string                `json:"orderNumber"`
+	ListingID             string                `json:"listingId"`
+	Amount                Money                 `json:"amount"`
+	CurrencyCode          string                `json:"currencyCode"`
+	CreatedAt             time.Time             `json:"createdAt"`
+	UpdatedAt             time.Time             `json:"updatedAt"`
+	Variant               VariantRef            `json:"variant"`
+	Product               ProductRef            `json:"product"`
+	Status                string                `json:"status"`
+	Shipment              Shipment              `json:"shipment"`
+	InitiatedShipments    InitiatedShipments    `json:"initiatedShipments"`
+	InventoryType         string                `json:"inventoryType"`
+	AuthenticationDetails AuthenticationDetails `json:"authenticationDetails"`
+	Payout                Payout                `json:"payout"`
+}
+
+// ToOrder returns the order as it appears in the active and historical order
+// lists, which do not include the shipment.
+func (r GetSingleOrderResponse) ToOrder() Order {
+	return Order{
+		OrderNumber:           r.OrderNumber,
+		ListingID:             r.ListingID,
+		AskID:                 r.AskID,
+		Amount:                r.Amount,
+		CurrencyCode:          r.CurrencyCode,
+		Status:                r.Status,
+		CreatedAt:             r.CreatedAt,
+		UpdatedAt:             r.UpdatedAt,
+		Product:               r.Product,
+		Variant:               r.Variant,
+		AuthenticationDetails: r.AuthenticationDetails,
+		Payout:                r.Payout,
+		InitiatedShipments:    r.InitiatedShipments,
+		InventoryType:         r.InventoryType,
+	}
+}
+
+// ToGetSingleOrderResponse returns the order in the shape of GetOrder with
+// an empty shipment; fetch the order with GetOrder to get it.
+func (o Order) ToGetSingleOrderResponse() GetSingleOrderResponse {
+	return GetSingleOrderResponse{
+		AskID:                 o.AskID,
+		OrderNumber:           o.OrderNumber,
+		ListingID:             o.ListingID,
+		Amount:                o.Amount,
+		CurrencyCode:          o.CurrencyCode,
+		CreatedAt:             o.CreatedAt,
+		UpdatedAt:             o.UpdatedAt,
+		Variant:               o.Variant,
+		Product:               o.Product,
+		Status:                o.Status,
+		InitiatedShipments:    o.InitiatedShipments,
+		InventoryType:         o.InventoryType,
+		AuthenticationDetails: o.AuthenticationDetails,
+		Payout:                o.Payout,
+	}
+}
+
+// UnmarshalJSON sets the currency of Amount, which StockX sends apart from
+// the amount.
+func (r *GetSingleOrderResponse) UnmarshalJSON(data []byte) error {
+	type getSingleOrderResponse GetSingleOrderResponse
+	if err := json.Unmarshal(data, (*getSingleOrderResponse)(r)); err != nil {
+		return err
+	}
+
+	r.Amount = r.Amount.WithCurrency(r.CurrencyCode)
+
+	return nil
 }
@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -130,6 +130,10 @@ func WithActiveInitiatedShipmentDisplayIDs(ids ...string) ActiveOrdersOption {
 }
 
 func (s *stockXClient) GetActiveOrders(opts ...ActiveOrdersOption) (OrdersResponse, error) {
+	return s.GetActiveOrdersContext(context.Background(), opts...)
+}
+
+func (s *stockXClient) GetActiveOrdersContext(ctx context.Context, opts ...ActiveOrdersOption) (OrdersResponse, error) {
 	req := &ActiveOrdersRequest{
 		PageNumber: 1,
 		PageSize:   20,
@@ -140,7 +144,7 @@ func (s *stockXClient) GetActiveOrders(opts ...ActiveOrdersOption) (OrdersRespon
 		opt(req)
 	}
 
-	u, err := url.Parse(OrdersGetActiveEndpoint)
+	u, err := url.Parse(s.endpoint(OrdersGetActiveEndpoint))
 	if err != nil {
 		return OrdersResponse{}, err
 	}
@@ -179,25 +183,18 @@ func (s *stockXClient) GetActiveOrders(opts ...ActiveOrdersOption) (OrdersRespon
 
 	u.RawQuery = q.Encode()
 
-	httpReq, err := http.NewRequest("GET", u.String(), nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return OrdersResponse{}, err
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	httpReq.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(httpReq)
+	resp, err := s.do(httpReq)
 	if err != nil {
 		return OrdersResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return OrdersResponse{}, err
-	}
-
 	var response OrdersResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return OrdersResponse{}, err
@@ -215,43 +212,30 @@ type OrdersResponse struct {
 }
 
 type Order struct {
-	OrderNumber  string    `json:"orderNumber"`
-	ListingID    string    `json:"listingId"`
-	AskID        string    `json:"askId"`
-	Amount       string    `json:"amount"`
-	CurrencyCode string    `json:"currencyCode"`
-	Status       string    `json:"status"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
-	Product      struct {
-		ProductID   string `json:"productId"`
-		ProductName string `json:"productName"`
-		StyleID     string `json:"styleId"`
-	} `json:"product"`
-	Variant struct {
-		VariantID    string `json:"variantId"`
-		VariantName  string `json:"variantName"`
-		VariantValue string `json:"variantValue"`
-	} `json:"variant"`
-	AuthenticationDetails struct {
-		Status       string `json:"status"`
-		FailureNotes string `json:"failureNotes"`
-	} `json:"authenticationDetails"`
-	Payout struct {
-		TotalPayout      float64 `json:"totalPayout"`
-		SalePrice        int     `json:"salePrice"`
-		TotalAdjustments int     `json:"totalAdjustments"`
-		CurrencyCode     string  `json:"currencyCode"`
-		Adjustments      []struct {
-			AdjustmentType string  `json:"adjustmentType"`
-			Amount         float64 `json:"amount"`
-			Percentage     float64 `json:"percentage"`
-		} `json:"adjustments"`
-	} `json:"payout"`
-	InitiatedShipments struct {
-		Inbound struct {
-			DisplayID string `json:"displayId"`
-		} `json:"inbound"`
-	} `json:"initiatedShipments"`
-	InventoryType string `json:"inventoryType"`
+	OrderNumber           string                `json:"orderNumber"`
+	ListingID             string                `json:"listingId"`
+	AskID                 string                `json:"askId"`
+	Amount                Money                 `json:"amount"`
+	CurrencyCode          string                `json:"currencyCode"`
+	Status                string                `json:"status"`
+	CreatedAt             time.Time             `json:"createdAt"`
+	UpdatedAt             time.Time             `json:"updatedAt"`
+	Product               ProductRef            `json:"product"`
+	Variant               VariantRef            `json:"variant"`
+	AuthenticationDetails AuthenticationDetails `json:"authenticationDetails"`
+	Payout                Payout                `json:"payout"`
+	InitiatedShipments    InitiatedShipments    `json:"initiatedShipments"`
+	InventoryType         string                `json:"inventoryType"`
+}
+
+// UnmarshalJSON decodes an order, giving the sale amount its currency.
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	if err := json.Unmarshal(data, (*order)(o)); err != nil {
+		return err
+	}
+
+	o.Amount = o.Amount.WithCurrency(o.CurrencyCode)
+
+	return nil
 }
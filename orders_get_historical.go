@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -83,6 +83,10 @@ func WithHistoricalInitiatedShipmentDisplayIds(initiatedShipmentDisplayIds ...st
 }
 
 func (s *stockXClient) GetHistoricalOrders(opts ...HistoricalOrdersOption) (OrdersResponse, error) {
+	return s.GetHistoricalOrdersContext(context.Background(), opts...)
+}
+
+func (s *stockXClient) GetHistoricalOrdersContext(ctx context.Context, opts ...HistoricalOrdersOption) (OrdersResponse, error) {
 	req := &HistoricalOrdersRequest{
 		PageNumber: 1,
 		PageSize:   20,
@@ -92,7 +96,7 @@ func (s *stockXClient) GetHistoricalOrders(opts ...HistoricalOrdersOption) (Orde
 		opt(req)
 	}
 
-	u, err := url.Parse(OrdersGetHistoricalEndpoint)
+	u, err := url.Parse(s.endpoint(OrdersGetHistoricalEndpoint))
 	if err != nil {
 		return OrdersResponse{}, err
 	}
@@ -135,25 +139,18 @@ func (s *stockXClient) GetHistoricalOrders(opts ...HistoricalOrdersOption) (Orde
 
 	u.RawQuery = q.Encode()
 
-	httpReq, err := http.NewRequest("GET", u.String(), nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return OrdersResponse{}, err
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	httpReq.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(httpReq)
+	resp, err := s.do(httpReq)
 	if err != nil {
 		return OrdersResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return OrdersResponse{}, err
-	}
-
 	var response OrdersResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return OrdersResponse{}, err
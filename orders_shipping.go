@@ -0,0 +1,221 @@
+package stockxgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoShippingDocument = errors.New("order has no shipping document")
+
+// ShippingDocument is a downloaded shipping label or document. The caller
+// must close Body.
+type ShippingDocument struct {
+	Body        io.ReadCloser
+	ContentType string
+	// ContentLength is the size of the document in bytes, or -1 if unknown.
+	ContentLength int64
+}
+
+// SaveShippingLabelsResult reports the outcome of SaveShippingLabels per
+// order number.
+type SaveShippingLabelsResult struct {
+	// Saved maps order numbers to the files their labels were written to.
+	Saved map[string]string
+	// Skipped lists orders that have no shipping label yet.
+	Skipped []string
+	Failed  map[string]error
+}
+
+func (s *stockXClient) GetShippingLabel(orderNumber string) (ShippingDocument, error) {
+	return s.GetShippingLabelContext(context.Background(), orderNumber)
+}
+
+// GetShippingLabelContext downloads the shipping label of an order. It
+// returns ErrNoShippingDocument when StockX has not issued a label yet.
+func (s *stockXClient) GetShippingLabelContext(ctx context.Context, orderNumber string) (ShippingDocument, error) {
+	order, err := s.GetOrderContext(ctx, orderNumber)
+	if err != nil {
+		return ShippingDocument{}, err
+	}
+
+	return s.downloadShippingDocument(ctx, order.Shipment.ShippingLabelURL)
+}
+
+func (s *stockXClient) GetShippingDocument(orderNumber string) (ShippingDocument, error) {
+	return s.GetShippingDocumentContext(context.Background(), orderNumber)
+}
+
+// GetShippingDocumentContext downloads the shipping document of an order,
+// such as a packing slip. It returns ErrNoShippingDocument when the order
+// has none.
+func (s *stockXClient) GetShippingDocumentContext(ctx context.Context, orderNumber string) (ShippingDocument, error) {
+	order, err := s.GetOrderContext(ctx, orderNumber)
+	if err != nil {
+		return ShippingDocument{}, err
+	}
+
+	return s.downloadShippingDocument(ctx, order.Shipment.ShippingDocumentURL)
+}
+
+// SaveShippingLabels downloads the labels of every active order in the given
+// status into dir, one file per order named after the order number. Orders
+// without a label are skipped and failed downloads do not stop the others;
+// the returned error is only set when the orders could not be listed or dir
+// could not be created.
+func (s *stockXClient) SaveShippingLabels(ctx context.Context, status OrderStatus, dir string) (SaveShippingLabelsResult, error) {
+	result := SaveShippingLabelsResult{
+		Saved:  make(map[string]string),
+		Failed: make(map[string]error),
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return result, err
+	}
+
+	for order, err := range s.ActiveOrdersIter(ctx, WithActiveOrderStatus(status), WithActivePageSize(100)) {
+		if err != nil {
+			return result, err
+		}
+
+		path, err := s.saveShippingLabel(ctx, order.OrderNumber, dir)
+		switch {
+		case errors.Is(err, ErrNoShippingDocument):
+			result.Skipped = append(result.Skipped, order.OrderNumber)
+		case err != nil:
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			result.Failed[order.OrderNumber] = err
+		default:
+			result.Saved[order.OrderNumber] = path
+		}
+	}
+
+	return result, nil
+}
+
+func (s *stockXClient) saveShippingLabel(ctx context.Context, orderNumber, dir string) (string, error) {
+	label, err := s.GetShippingLabelContext(ctx, orderNumber)
+	if err != nil {
+		return "", err
+	}
+	defer label.Body.Close()
+
+	path := filepath.Join(dir, safeFileName(orderNumber)+documentExtension(label.ContentType))
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(file, label.Body); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// downloadShippingDocument fetches a document URL from an order. Documents
+// hosted on the StockX API are requested with the client's credentials;
+// credentials are never sent to other hosts, which serve pre-signed URLs.
+func (s *stockXClient) downloadShippingDocument(ctx context.Context, documentURL string) (ShippingDocument, error) {
+	if documentURL == "" {
+		return ShippingDocument{}, ErrNoShippingDocument
+	}
+
+	target, err := s.resolveDocumentURL(documentURL)
+	if err != nil {
+		return ShippingDocument{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target.String(), nil)
+	if err != nil {
+		return ShippingDocument{}, err
+	}
+
+	req.Header.Set("Accept", "application/pdf, image/*, */*")
+
+	var resp *http.Response
+	if s.isAPIHost(target) {
+		resp, err = s.do(req)
+	} else {
+		resp, err = s.send(req)
+	}
+	if err != nil {
+		return ShippingDocument{}, err
+	}
+
+	return ShippingDocument{
+		Body:          resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}, nil
+}
+
+func (s *stockXClient) resolveDocumentURL(documentURL string) (*url.URL, error) {
+	base, err := url.Parse(s.endpoint(DefaultBaseURL))
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := url.Parse(s.endpoint(documentURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipping document URL %q: %w", documentURL, err)
+	}
+
+	return base.ResolveReference(ref), nil
+}
+
+func (s *stockXClient) isAPIHost(u *url.URL) bool {
+	base, err := url.Parse(s.endpoint(DefaultBaseURL))
+	return err == nil && strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// documentExtension returns the file extension for a content type, falling
+// back to .pdf, the format StockX issues labels in.
+func documentExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ".pdf"
+	}
+
+	switch mediaType {
+	case "application/pdf":
+		return ".pdf"
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	}
+
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+
+	return ".pdf"
+}
+
+// safeFileName replaces characters that cannot appear in a file name.
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == 0 {
+			return '_'
+		}
+		return r
+	}, name)
+}
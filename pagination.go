@@ -0,0 +1,135 @@
+package stockxgo
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// paginate walks pages starting at start until fetch reports there is no
+// next page. It yields the first error it meets, including context
+// cancellation, and stops.
+func paginate[T any](ctx context.Context, start int, fetch func(ctx context.Context, page int) ([]T, bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for page := start; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, hasNextPage, err := fetch(ctx, page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !hasNextPage || len(items) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Prefetch reads up to buffer items ahead of the consumer in a background
+// goroutine, so the next page is fetched while the current one is processed.
+// Use a buffer of at least the page size to always keep a full page ahead.
+// When the consumer stops early, Prefetch waits for an in-flight page request
+// to finish before returning.
+func Prefetch[T any](seq iter.Seq2[T, error], buffer int) iter.Seq2[T, error] {
+	type item struct {
+		value T
+		err   error
+	}
+
+	return func(yield func(T, error) bool) {
+		items := make(chan item, buffer)
+		stop := make(chan struct{})
+
+		go func() {
+			defer close(items)
+			for value, err := range seq {
+				select {
+				case items <- item{value, err}:
+				case <-stop:
+					return
+				}
+			}
+		}()
+
+		defer func() {
+			close(stop)
+			for range items {
+			}
+		}()
+
+		for it := range items {
+			if !yield(it.value, it.err) {
+				return
+			}
+		}
+	}
+}
+
+// ListingsIter iterates over every listing matching opts, fetching pages
+// lazily. A page number option sets the page to start from.
+func (s *stockXClient) ListingsIter(ctx context.Context, opts ...GetAllListingsOption) iter.Seq2[Listing, error] {
+	request := &GetAllListingsRequest{pageNumber: 1}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	return paginate(ctx, request.pageNumber, func(ctx context.Context, page int) ([]Listing, bool, error) {
+		resp, err := s.GetAllListingsContext(ctx, append(slices.Clone(opts), WithGetAllListingsPageNumber(page))...)
+		return resp.Listings, resp.HasNextPage, err
+	})
+}
+
+// ActiveOrdersIter iterates over every active order matching opts, fetching
+// pages lazily. A page number option sets the page to start from.
+func (s *stockXClient) ActiveOrdersIter(ctx context.Context, opts ...ActiveOrdersOption) iter.Seq2[Order, error] {
+	request := &ActiveOrdersRequest{PageNumber: 1}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	return paginate(ctx, request.PageNumber, func(ctx context.Context, page int) ([]Order, bool, error) {
+		resp, err := s.GetActiveOrdersContext(ctx, append(slices.Clone(opts), WithActivePageNumber(page))...)
+		return resp.Orders, resp.HasNextPage, err
+	})
+}
+
+// HistoricalOrdersIter iterates over every historical order matching opts,
+// fetching pages lazily. A page number option sets the page to start from.
+func (s *stockXClient) HistoricalOrdersIter(ctx context.Context, opts ...HistoricalOrdersOption) iter.Seq2[Order, error] {
+	request := &HistoricalOrdersRequest{PageNumber: 1}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	return paginate(ctx, request.PageNumber, func(ctx context.Context, page int) ([]Order, bool, error) {
+		resp, err := s.GetHistoricalOrdersContext(ctx, append(slices.Clone(opts), WithHistoricalPageNumber(page))...)
+		return resp.Orders, resp.HasNextPage, err
+	})
+}
+
+// SearchCatalogIter iterates over every product matching opts, fetching pages
+// lazily. A page number option sets the page to start from.
+func (s *stockXClient) SearchCatalogIter(ctx context.Context, opts ...SearchCatalogOption) iter.Seq2[Product, error] {
+	request := &SearchCatalogRequest{PageNumber: 1}
+	for _, opt := range opts {
+		opt(request)
+	}
+
+	return paginate(ctx, request.PageNumber, func(ctx context.Context, page int) ([]Product, bool, error) {
+		resp, err := s.SearchCatalogContext(ctx, append(slices.Clone(opts), WithSearchCatalogPageNumber(page))...)
+		return resp.Products, resp.HasNextPage, err
+	})
+}
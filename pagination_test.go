@@ -0,0 +1,135 @@
+package stockxgo
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// pager serves pages of size two out of items and counts the pages fetched.
+type pager struct {
+	items   []int
+	fetched []int
+	failAt  int
+}
+
+func (p *pager) fetch(ctx context.Context, page int) ([]int, bool, error) {
+	p.fetched = append(p.fetched, page)
+	if page == p.failAt {
+		return nil, false, errors.New("page failed")
+	}
+
+	start := min((page-1)*2, len(p.items))
+	end := min(start+2, len(p.items))
+	return p.items[start:end], end < len(p.items), nil
+}
+
+func collect(seq iter.Seq2[int, error]) ([]int, error) {
+	var values []int
+	for v, err := range seq {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+	values, err := collect(paginate(ctx, 1, p.fetch))
+	if err != nil || !slices.Equal(values, []int{1, 2, 3, 4, 5}) || !slices.Equal(p.fetched, []int{1, 2, 3}) {
+		t.Errorf("full walk = %v, %v after fetching pages %v", values, err, p.fetched)
+	}
+
+	p = &pager{items: []int{1, 2, 3, 4, 5}}
+	values, _ = collect(paginate(ctx, 2, p.fetch))
+	if !slices.Equal(values, []int{3, 4, 5}) {
+		t.Errorf("walk from page 2 = %v", values)
+	}
+
+	// Stopping early does not fetch the remaining pages.
+	p = &pager{items: []int{1, 2, 3, 4, 5, 6, 7, 8}}
+	for v := range paginate(ctx, 1, p.fetch) {
+		if v == 3 {
+			break
+		}
+	}
+	if !slices.Equal(p.fetched, []int{1, 2}) {
+		t.Errorf("early stop fetched pages %v, want [1 2]", p.fetched)
+	}
+
+	// An error is yielded once and ends the walk.
+	p = &pager{items: []int{1, 2, 3, 4, 5}, failAt: 2}
+	var errs int
+	for _, err := range paginate(ctx, 1, p.fetch) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 || !slices.Equal(p.fetched, []int{1, 2}) {
+		t.Errorf("failing page yielded %d errors after fetching pages %v", errs, p.fetched)
+	}
+
+	// Cancelling the context stops before the next page.
+	p = &pager{items: []int{1, 2, 3, 4, 5}}
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	values = nil
+	for v, err := range paginate(cancelCtx, 1, p.fetch) {
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("error after cancel = %v, want context.Canceled", err)
+			}
+			continue
+		}
+		values = append(values, v)
+		cancel()
+	}
+	if !slices.Equal(values, []int{1, 2}) || !slices.Equal(p.fetched, []int{1}) {
+		t.Errorf("cancelled walk = %v after fetching pages %v", values, p.fetched)
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+
+	p := &pager{items: []int{1, 2, 3, 4, 5}}
+	values, err := collect(Prefetch(paginate(ctx, 1, p.fetch), 2))
+	if err != nil || !slices.Equal(values, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Prefetch = %v, %v", values, err)
+	}
+
+	p = &pager{items: []int{1, 2, 3, 4, 5}, failAt: 2}
+	values, err = collect(Prefetch(paginate(ctx, 1, p.fetch), 2))
+	if err == nil || !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Prefetch of a failing walk = %v, %v", values, err)
+	}
+
+	// Breaking out stops the producer before Prefetch returns.
+	exited := make(chan struct{})
+	endless := func(yield func(int, error) bool) {
+		defer close(exited)
+		for i := 0; ; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+
+	for v := range Prefetch(endless, 4) {
+		if v == 10 {
+			break
+		}
+	}
+
+	select {
+	case <-exited:
+	default:
+		t.Error("the producer goroutine was still running after the consumer stopped")
+	}
+}
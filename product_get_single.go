@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetSingleProduct(productID string) (Product, error) {
-	url := fmt.Sprintf(ProductGetSingleEndpoint, productID)
+	return s.GetSingleProductContext(context.Background(), productID)
+}
+
+func (s *stockXClient) GetSingleProductContext(ctx context.Context, productID string) (Product, error) {
+	url := s.endpoint(ProductGetSingleEndpoint, productID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return Product{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return Product{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return Product{}, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return Product{}, err
@@ -58,7 +55,7 @@ type Product struct {
 		Gender      string `json:"gender"`
 		Season      string `json:"season"`
 		ReleaseDate string `json:"releaseDate"`
-		RetailPrice int    `json:"retailPrice"`
+		RetailPrice Money  `json:"retailPrice"`
 		Colorway    string `json:"colorway"`
 		Color       string `json:"color"`
 	} `json:"productAttributes"`
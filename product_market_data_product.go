@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetProductMarketData(productID, currencyCode string) ([]MarketData, error) {
-	url := fmt.Sprintf(ProductMarketDataProductEndpoint, productID, currencyCode)
+	return s.GetProductMarketDataContext(context.Background(), productID, currencyCode)
+}
+
+func (s *stockXClient) GetProductMarketDataContext(ctx context.Context, productID, currencyCode string) ([]MarketData, error) {
+	url := s.endpoint(ProductMarketDataProductEndpoint, productID, s.currency(currencyCode))
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return []MarketData{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return []MarketData{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return nil, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return []MarketData{}, err
@@ -44,5 +41,7 @@ func (s *stockXClient) GetProductMarketData(productID, currencyCode string) ([]M
 		return []MarketData{}, err
 	}
 
+	s.recordSnapshots(ctx, productMarketData...)
+
 	return productMarketData, nil
 }
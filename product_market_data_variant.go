@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetProductMarketDataForVariant(productID, variantID, currencyCode string) (MarketData, error) {
-	url := fmt.Sprintf(ProductMarketDataVariantEndpoint, productID, variantID, currencyCode)
+	return s.GetProductMarketDataForVariantContext(context.Background(), productID, variantID, currencyCode)
+}
+
+func (s *stockXClient) GetProductMarketDataForVariantContext(ctx context.Context, productID, variantID, currencyCode string) (MarketData, error) {
+	url := s.endpoint(ProductMarketDataVariantEndpoint, productID, variantID, s.currency(currencyCode))
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return MarketData{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return MarketData{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return MarketData{}, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return MarketData{}, err
@@ -44,6 +41,8 @@ func (s *stockXClient) GetProductMarketDataForVariant(productID, variantID, curr
 		return MarketData{}, err
 	}
 
+	s.recordSnapshots(ctx, productMarketDataVariant)
+
 	return productMarketDataVariant, nil
 }
 
@@ -51,9 +50,25 @@ type MarketData struct {
 	ProductID           string `json:"productId"`
 	VariantID           string `json:"variantId"`
 	CurrencyCode        string `json:"currencyCode"`
-	LowestAskAmount     string `json:"lowestAskAmount"`
-	HighestBidAmount    string `json:"highestBidAmount"`
-	SellFasterAmount    string `json:"sellFasterAmount"`
-	EarnMoreAmount      string `json:"earnMoreAmount"`
-	FlexLowestAskAmount string `json:"flexLowestAskAmount"`
+	LowestAskAmount     Money  `json:"lowestAskAmount"`
+	HighestBidAmount    Money  `json:"highestBidAmount"`
+	SellFasterAmount    Money  `json:"sellFasterAmount"`
+	EarnMoreAmount      Money  `json:"earnMoreAmount"`
+	FlexLowestAskAmount Money  `json:"flexLowestAskAmount"`
+}
+
+// UnmarshalJSON decodes market data and puts every amount in CurrencyCode.
+func (d *MarketData) UnmarshalJSON(data []byte) error {
+	type marketData MarketData
+	if err := json.Unmarshal(data, (*marketData)(d)); err != nil {
+		return err
+	}
+
+	d.LowestAskAmount = d.LowestAskAmount.WithCurrency(d.CurrencyCode)
+	d.HighestBidAmount = d.HighestBidAmount.WithCurrency(d.CurrencyCode)
+	d.SellFasterAmount = d.SellFasterAmount.WithCurrency(d.CurrencyCode)
+	d.EarnMoreAmount = d.EarnMoreAmount.WithCurrency(d.CurrencyCode)
+	d.FlexLowestAskAmount = d.FlexLowestAskAmount.WithCurrency(d.CurrencyCode)
+
+	return nil
 }
@@ -1,6 +1,7 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,10 @@ import (
 	"net/url"
 )
 
+var (
+	SearchCatalogEndpoint = "https://api.stockx.com/v2/catalog/search"
+)
+
 type SearchCatalogRequest struct {
 	Query      string
 	PageNumber int
@@ -35,6 +40,10 @@ func WithSearchCatalogQuery(query string) SearchCatalogOption {
 }
 
 func (s *stockXClient) SearchCatalog(opts ...SearchCatalogOption) (SearchCatalogResponse, error) {
+	return s.SearchCatalogContext(context.Background(), opts...)
+}
+
+func (s *stockXClient) SearchCatalogContext(ctx context.Context, opts ...SearchCatalogOption) (SearchCatalogResponse, error) {
 	request := &SearchCatalogRequest{
 		PageNumber: 1,
 		PageSize:   10,
@@ -49,27 +58,20 @@ func (s *stockXClient) SearchCatalog(opts ...SearchCatalogOption) (SearchCatalog
 	queryParams.Add("pageNumber", fmt.Sprintf("%d", request.PageNumber))
 	queryParams.Add("pageSize", fmt.Sprintf("%d", request.PageSize))
 
-	url := fmt.Sprintf("https://api.stockx.com/v2/catalog/search?%s", queryParams.Encode())
+	url := fmt.Sprintf("%s?%s", s.endpoint(SearchCatalogEndpoint), queryParams.Encode())
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return SearchCatalogResponse{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return SearchCatalogResponse{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return SearchCatalogResponse{}, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return SearchCatalogResponse{}, err
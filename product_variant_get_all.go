@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetAllProductVariants(productID string) ([]ProductVariant, error) {
-	url := fmt.Sprintf(ProductVariantGetAllEndpoint, productID)
+	return s.GetAllProductVariantsContext(context.Background(), productID)
+}
+
+func (s *stockXClient) GetAllProductVariantsContext(ctx context.Context, productID string) ([]ProductVariant, error) {
+	url := s.endpoint(ProductVariantGetAllEndpoint, productID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return nil, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -1,8 +1,8 @@
 package stockxgo
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -12,27 +12,24 @@ var (
 )
 
 func (s *stockXClient) GetSingleProductVariant(productID, variantID string) (ProductVariant, error) {
-	url := fmt.Sprintf(ProductVariantGetSingleEndpoint, productID, variantID)
+	return s.GetSingleProductVariantContext(context.Background(), productID, variantID)
+}
+
+func (s *stockXClient) GetSingleProductVariantContext(ctx context.Context, productID, variantID string) (ProductVariant, error) {
+	url := s.endpoint(ProductVariantGetSingleEndpoint, productID, variantID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return ProductVariant{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.session.AccessToken))
-	req.Header.Set("x-api-key", s.apiKey)
-
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return ProductVariant{}, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := statusCode(resp.StatusCode); err != nil {
-		return ProductVariant{}, err
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return ProductVariant{}, err
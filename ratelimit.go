@@ -0,0 +1,254 @@
+package stockxgo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointFamily groups API endpoints that share a request quota.
+type EndpointFamily string
+
+const (
+	EndpointFamilyCatalog EndpointFamily = "catalog"
+	EndpointFamilySelling EndpointFamily = "selling"
+	EndpointFamilyOrders  EndpointFamily = "orders"
+)
+
+// RateLimit describes a token bucket: Rate tokens are added per second, up to
+// Burst tokens.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits configures one bucket per endpoint family. Families without an
+// entry are not throttled.
+type RateLimits map[EndpointFamily]RateLimit
+
+// DefaultRateLimits returns a conservative starting point of one request per
+// second per endpoint family, with a small burst.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		EndpointFamilyCatalog: {Rate: 1, Burst: 5},
+		EndpointFamilySelling: {Rate: 1, Burst: 5},
+		EndpointFamilyOrders:  {Rate: 1, Burst: 5},
+	}
+}
+
+// BucketState is a snapshot of a rate limit bucket.
+type BucketState struct {
+	Family EndpointFamily
+	Rate   float64
+	Burst  int
+	// Tokens is the number of requests that can be sent right now.
+	Tokens float64
+	// BlockedUntil is set when StockX reported the quota as exhausted.
+	BlockedUntil time.Time
+	// Limit and Remaining mirror the last rate limit headers seen for the
+	// family, or -1 when StockX has not sent them.
+	Limit     int
+	Remaining int
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointFamily]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	limit        int
+	remaining    int
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	l := &rateLimiter{
+		buckets: make(map[EndpointFamily]*bucket, len(limits)),
+		now:     time.Now,
+	}
+
+	now := l.now()
+	for family, limit := range limits {
+		if limit.Rate <= 0 {
+			continue
+		}
+		burst := float64(limit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		l.buckets[family] = &bucket{
+			rate:      limit.Rate,
+			burst:     burst,
+			tokens:    burst,
+			last:      now,
+			limit:     -1,
+			remaining: -1,
+		}
+	}
+
+	return l
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// wait blocks until a request for family may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, family EndpointFamily) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		b, ok := l.buckets[family]
+		if !ok {
+			l.mu.Unlock()
+			return nil
+		}
+
+		now := l.now()
+		b.refill(now)
+
+		var delay time.Duration
+		switch {
+		case now.Before(b.blockedUntil):
+			delay = b.blockedUntil.Sub(now)
+		case b.tokens >= 1:
+			b.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// observe adapts the bucket for family to the rate limit headers of resp.
+func (l *rateLimiter) observe(family EndpointFamily, resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[family]
+	if !ok {
+		return
+	}
+
+	now := l.now()
+	b.refill(now)
+
+	if limit, ok := headerInt(resp.Header, "X-RateLimit-Limit"); ok {
+		b.limit = limit
+	}
+
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining")
+	if hasRemaining {
+		b.remaining = remaining
+		if float64(remaining) < b.tokens {
+			b.tokens = float64(remaining)
+		}
+	}
+
+	if hasRemaining && remaining == 0 {
+		if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok && reset.After(b.blockedUntil) {
+			b.blockedUntil = reset
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.tokens = 0
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			if until := now.Add(wait); until.After(b.blockedUntil) {
+				b.blockedUntil = until
+			}
+		}
+	}
+}
+
+func (l *rateLimiter) state() map[EndpointFamily]BucketState {
+	states := make(map[EndpointFamily]BucketState)
+	if l == nil {
+		return states
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for family, b := range l.buckets {
+		b.refill(now)
+		states[family] = BucketState{
+			Family:       family,
+			Rate:         b.rate,
+			Burst:        int(b.burst),
+			Tokens:       b.tokens,
+			BlockedUntil: b.blockedUntil,
+			Limit:        b.limit,
+			Remaining:    b.remaining,
+		}
+	}
+
+	return states
+}
+
+// endpointFamily maps a request URL path to the quota it counts against.
+func endpointFamily(path string) EndpointFamily {
+	switch {
+	case strings.Contains(path, "/catalog/"):
+		return EndpointFamilyCatalog
+	case strings.Contains(path, "/selling/orders"):
+		return EndpointFamilyOrders
+	case strings.Contains(path, "/selling/"):
+		return EndpointFamilySelling
+	default:
+		return ""
+	}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v, err := strconv.Atoi(strings.TrimSpace(h.Get(key)))
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// parseRateLimitReset accepts either a Unix timestamp or a number of seconds
+// until the quota resets.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+
+	// Anything larger than a year in seconds is taken as an epoch timestamp.
+	if n > 365*24*60*60 {
+		return time.Unix(n, 0), true
+	}
+
+	return now.Add(time.Duration(n) * time.Second), true
+}
@@ -0,0 +1,126 @@
+package stockxgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock only moves when advance is
+// called.
+func newTestLimiter(limits RateLimits) (*rateLimiter, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(limits)
+	l.now = func() time.Time { return now }
+	for _, b := range l.buckets {
+		b.last = now
+	}
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	l, advance := newTestLimiter(RateLimits{EndpointFamilyCatalog: {Rate: 2, Burst: 2}})
+	ctx := context.Background()
+
+	for i := range 2 {
+		if err := l.wait(ctx, EndpointFamilyCatalog); err != nil {
+			t.Fatalf("wait #%d: %v", i+1, err)
+		}
+	}
+
+	// The bucket is empty, so the next request blocks until ctx gives up.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.wait(cancelled, EndpointFamilyCatalog); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait on an empty bucket = %v, want context.Canceled", err)
+	}
+
+	advance(250 * time.Millisecond)
+	if tokens := l.state()[EndpointFamilyCatalog].Tokens; tokens != 0.5 {
+		t.Errorf("tokens after 250ms = %v, want 0.5", tokens)
+	}
+
+	advance(time.Hour)
+	if tokens := l.state()[EndpointFamilyCatalog].Tokens; tokens != 2 {
+		t.Errorf("tokens after an hour = %v, want the burst of 2", tokens)
+	}
+
+	// Families without a bucket are not throttled.
+	if err := l.wait(cancelled, EndpointFamilyOrders); err != nil {
+		t.Errorf("wait for an unthrottled family = %v", err)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	l, advance := newTestLimiter(RateLimits{EndpointFamilySelling: {Rate: 1, Burst: 10}})
+	start := l.now()
+
+	respond := func(status int, header map[string]string) {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range header {
+			resp.Header.Set(k, v)
+		}
+		l.observe(EndpointFamilySelling, resp)
+	}
+
+	respond(http.StatusOK, map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "3"})
+	state := l.state()[EndpointFamilySelling]
+	if state.Limit != 100 || state.Remaining != 3 || state.Tokens != 3 || !state.BlockedUntil.IsZero() {
+		t.Errorf("after remaining 3: %+v", state)
+	}
+
+	respond(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"})
+	state = l.state()[EndpointFamilySelling]
+	if state.Tokens != 0 || !state.BlockedUntil.Equal(start.Add(30*time.Second)) {
+		t.Errorf("after remaining 0: %+v, want blocked for 30s", state)
+	}
+
+	// A shorter Retry-After does not lift an existing block.
+	respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"})
+	if state := l.state()[EndpointFamilySelling]; !state.BlockedUntil.Equal(start.Add(30 * time.Second)) {
+		t.Errorf("after a shorter Retry-After: blocked until %s", state.BlockedUntil)
+	}
+
+	advance(time.Minute)
+	respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "10"})
+	state = l.state()[EndpointFamilySelling]
+	if state.Tokens != 0 || !state.BlockedUntil.Equal(start.Add(70*time.Second)) {
+		t.Errorf("after 429: %+v, want blocked for 10s", state)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	advance(time.Hour)
+	if err := l.wait(cancelled, EndpointFamilySelling); err != nil {
+		t.Errorf("wait after the block lifted = %v", err)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{value: "30", want: now.Add(30 * time.Second), ok: true},
+		{value: " 0 ", want: now, ok: true},
+		{value: "31536000", want: now.Add(365 * 24 * time.Hour), ok: true},
+		{value: "1704067260", want: now.Add(time.Minute), ok: true},
+		{value: ""},
+		{value: "-1"},
+		{value: "soon"},
+		{value: "1.5"},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRateLimitReset(tt.value, now)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseRateLimitReset(%q) = %s, %t; want %s, %t", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
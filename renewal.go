@@ -0,0 +1,298 @@
+package stockxgo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	defaultRenewalWindow    = 72 * time.Hour
+	defaultRenewalExtension = 30 * 24 * time.Hour
+)
+
+// RenewalOutcome is what the renewal scheduler did with a listing
+type RenewalOutcome string
+
+const (
+	// RenewalOutcomeRenewed means StockX has applied the new expiry.
+	RenewalOutcomeRenewed RenewalOutcome = "RENEWED"
+	// RenewalOutcomeQueued means StockX accepted the renewal but had not
+	// applied it yet. Its OperationID can be passed to WaitForOperation.
+	RenewalOutcomeQueued  RenewalOutcome = "QUEUED"
+	RenewalOutcomeDryRun  RenewalOutcome = "DRY_RUN"
+	RenewalOutcomeSkipped RenewalOutcome = "SKIPPED"
+	RenewalOutcomeFailed  RenewalOutcome = "FAILED"
+)
+
+// ListingRenewal records the renewal decision for one listing.
+type ListingRenewal struct {
+	ListingID    string         `json:"listingId"`
+	ProductID    string         `json:"productId"`
+	VariantID    string         `json:"variantId"`
+	Status       ListingStatus  `json:"status"`
+	OldExpiresAt time.Time      `json:"oldExpiresAt"`
+	NewExpiresAt time.Time      `json:"newExpiresAt"`
+	Outcome      RenewalOutcome `json:"outcome"`
+	// Reason explains skipped and failed listings.
+	Reason string `json:"reason,omitempty"`
+	// OperationID is the listing operation queued by a renewal.
+	OperationID string `json:"operationId,omitempty"`
+}
+
+// RenewalReport lists the decision taken for every listing of a scan.
+type RenewalReport struct {
+	Renewals []ListingRenewal
+}
+
+// ByOutcome returns the renewals with the given outcome.
+func (r RenewalReport) ByOutcome(outcome RenewalOutcome) []ListingRenewal {
+	var renewals []ListingRenewal
+	for _, renewal := range r.Renewals {
+		if renewal.Outcome == outcome {
+			renewals = append(renewals, renewal)
+		}
+	}
+
+	return renewals
+}
+
+// WriteTo writes the report as a table.
+func (r RenewalReport) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	tw := tabwriter.NewWriter(cw, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "LISTING\tVARIANT\tSTATUS\tEXPIRES\tNEW EXPIRY\tOUTCOME\tREASON")
+	for _, r := range r.Renewals {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.ListingID, r.VariantID, r.Status, formatExpiry(r.OldExpiresAt), formatExpiry(r.NewExpiresAt), r.Outcome, r.Reason)
+	}
+
+	err := tw.Flush()
+	return cw.n, err
+}
+
+// RenewalScheduler keeps asks alive by extending the expiry of those about
+// to expire. Each call to Run is one scan.
+type RenewalScheduler struct {
+	client     StockXClient
+	window     time.Duration
+	extension  time.Duration
+	reactivate bool
+	dryRun     bool
+	wait       bool
+}
+
+type RenewalOption func(*RenewalScheduler)
+
+// WithRenewalWindow sets how close to its expiry an ask is renewed
+// Defaults to 72 hours
+func WithRenewalWindow(window time.Duration) RenewalOption {
+	return func(r *RenewalScheduler) {
+		r.window = window
+	}
+}
+
+// WithRenewalExtension sets how far from now a renewed ask expires
+// Defaults to 30 days
+func WithRenewalExtension(extension time.Duration) RenewalOption {
+	return func(r *RenewalScheduler) {
+		r.extension = extension
+	}
+}
+
+// WithRenewalReactivateExpired also renews inactive listings whose ask has
+// already expired, by activating them again at their last price. Listings
+// deactivated by hand keep their expiry and are left alone.
+func WithRenewalReactivateExpired(reactivate bool) RenewalOption {
+	return func(r *RenewalScheduler) {
+		r.reactivate = reactivate
+	}
+}
+
+// WithRenewalDryRun makes the scheduler report the renewals it would make
+// without changing any listing.
+func WithRenewalDryRun(dryRun bool) RenewalOption {
+	return func(r *RenewalScheduler) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithRenewalWait makes the scheduler wait for every renewal operation to
+// complete with WaitForOperation, so renewals are reported as RENEWED or
+// FAILED instead of QUEUED.
+func WithRenewalWait(wait bool) RenewalOption {
+	return func(r *RenewalScheduler) {
+		r.wait = wait
+	}
+}
+
+func NewRenewalScheduler(client StockXClient, opts ...RenewalOption) *RenewalScheduler {
+	r := &RenewalScheduler{
+		client:    client,
+		window:    defaultRenewalWindow,
+		extension: defaultRenewalExtension,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run scans the active listings of the account, and the inactive ones when
+// reactivation is enabled, and renews those expiring within the window.
+func (r *RenewalScheduler) Run(ctx context.Context) (RenewalReport, error) {
+	statuses := []ListingStatus{ListingStatusActive}
+	if r.reactivate {
+		statuses = append(statuses, ListingStatusInactive)
+	}
+
+	var listings []Listing
+	for listing, err := range r.client.ListingsIter(ctx, WithGetAllListingsListingStatuses(statuses)) {
+		if err != nil {
+			return RenewalReport{}, err
+		}
+		listings = append(listings, listing)
+	}
+
+	return r.Renew(ctx, listings)
+}
+
+// Renew renews the given listings in order. Active asks are extended with
+// UpdateListing and expired inactive ones are activated again with
+// ActivateListing. A renewal StockX has not applied by the time it answers
+// is reported as QUEUED unless WithRenewalWait is set. A failed renewal is
+// reported and does not stop the others; Renew only returns an error when
+// the context is cancelled.
+func (r *RenewalScheduler) Renew(ctx context.Context, listings []Listing) (RenewalReport, error) {
+	var report RenewalReport
+
+	for _, listing := range listings {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		now := time.Now()
+		renewal := ListingRenewal{
+			ListingID:    listing.ListingID,
+			ProductID:    listing.Product.ProductID,
+			VariantID:    listing.Variant.VariantID,
+			Status:       listing.Status,
+			OldExpiresAt: listing.Ask.AskExpiresAt,
+			NewExpiresAt: now.Add(r.extension).UTC().Truncate(time.Second),
+		}
+
+		if reason := r.skipReason(listing, renewal.NewExpiresAt, now); reason != "" {
+			renewal.NewExpiresAt = time.Time{}
+			renewal.Outcome = RenewalOutcomeSkipped
+			renewal.Reason = reason
+		} else {
+			r.apply(ctx, listing, &renewal)
+		}
+
+		report.Renewals = append(report.Renewals, renewal)
+	}
+
+	return report, nil
+}
+
+// RunEvery calls Run every interval until ctx ends and hands each report to
+// handler. It returns the error of ctx.
+func (r *RenewalScheduler) RunEvery(ctx context.Context, interval time.Duration, handler func(RenewalReport, error)) error {
+	for {
+		report, err := r.Run(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		handler(report, err)
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// skipReason returns why a listing is not renewed, or an empty string when
+// it should be.
+func (r *RenewalScheduler) skipReason(listing Listing, newExpiresAt, now time.Time) string {
+	expiresAt := listing.Ask.AskExpiresAt
+
+	switch {
+	case listing.Status == ListingStatusInactive && !r.reactivate:
+		return "listing is INACTIVE"
+	case listing.Status == ListingStatusInactive && (expiresAt.IsZero() || expiresAt.After(now)):
+		return "listing was deactivated before its ask expired"
+	case listing.Status != ListingStatusActive && listing.Status != ListingStatusInactive:
+		return fmt.Sprintf("listing is %s", listing.Status)
+	case expiresAt.IsZero():
+		return "ask has no expiry"
+	case expiresAt.After(now.Add(r.window)):
+		return "ask does not expire within the renewal window"
+	case !newExpiresAt.After(expiresAt):
+		return "renewal would not extend the expiry"
+	case !listing.Amount.IsSet():
+		return "listing has no amount"
+	}
+
+	return ""
+}
+
+func (r *RenewalScheduler) apply(ctx context.Context, listing Listing, renewal *ListingRenewal) {
+	if r.dryRun {
+		renewal.Outcome = RenewalOutcomeDryRun
+		return
+	}
+
+	expiresAt := renewal.NewExpiresAt.Format(time.RFC3339)
+
+	var response ListingModificationResponse
+	var err error
+	if listing.Status == ListingStatusInactive {
+		response, err = r.client.ActivateListingContext(ctx, listing.ListingID, ActivateListingPayload{
+			Amount:       listing.Amount,
+			CurrencyCode: listing.CurrencyCode,
+			ExpiresAt:    expiresAt,
+		})
+	} else {
+		response, err = r.client.UpdateListingContext(ctx, listing.ListingID, UpdateListingPayload{
+			Amount:       listing.Amount,
+			CurrencyCode: listing.CurrencyCode,
+			ExpiresAt:    expiresAt,
+		})
+	}
+	if err != nil {
+		renewal.Outcome = RenewalOutcomeFailed
+		renewal.Reason = err.Error()
+		return
+	}
+
+	renewal.OperationID = response.OperationID
+	status := response.OperationStatus
+
+	if r.wait && status != OperationStatusSucceeded && response.OperationID != "" {
+		operation, err := r.client.WaitForOperation(ctx, listing.ListingID, response.OperationID)
+		if err != nil {
+			renewal.Outcome = RenewalOutcomeFailed
+			renewal.Reason = err.Error()
+			return
+		}
+		status = operation.OperationStatus
+	}
+
+	if status == OperationStatusSucceeded {
+		renewal.Outcome = RenewalOutcomeRenewed
+	} else {
+		renewal.Outcome = RenewalOutcomeQueued
+	}
+}
+
+func formatExpiry(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
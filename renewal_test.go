@@ -0,0 +1,135 @@
+package stockxgo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+func renewalListing(status stockxgo.ListingStatus, expiresIn time.Duration, amount string) stockxgo.Listing {
+	listing := stockxgo.Listing{Status: status, CurrencyCode: "USD"}
+	if amount != "" {
+		listing.Amount = usd(amount)
+	}
+	if expiresIn != 0 {
+		listing.Ask.AskExpiresAt = time.Now().Add(expiresIn)
+	}
+	return listing
+}
+
+func TestRenewalSkipReasons(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+	defer client.Close()
+
+	tests := []struct {
+		name       string
+		listing    stockxgo.Listing
+		reactivate bool
+		want       stockxgo.RenewalOutcome
+	}{
+		{name: "expiring", listing: renewalListing(stockxgo.ListingStatusActive, time.Hour, "100"), want: stockxgo.RenewalOutcomeDryRun},
+		{name: "outside window", listing: renewalListing(stockxgo.ListingStatusActive, 5*24*time.Hour, "100"), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "no expiry", listing: renewalListing(stockxgo.ListingStatusActive, 0, "100"), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "no amount", listing: renewalListing(stockxgo.ListingStatusActive, time.Hour, ""), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "expires after extension", listing: renewalListing(stockxgo.ListingStatusActive, 60*24*time.Hour, "100"), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "deleted", listing: renewalListing(stockxgo.ListingStatusDeleted, time.Hour, "100"), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "inactive", listing: renewalListing(stockxgo.ListingStatusInactive, -time.Hour, "100"), want: stockxgo.RenewalOutcomeSkipped},
+		{name: "expired inactive", listing: renewalListing(stockxgo.ListingStatusInactive, -time.Hour, "100"), reactivate: true, want: stockxgo.RenewalOutcomeDryRun},
+		{name: "deactivated by hand", listing: renewalListing(stockxgo.ListingStatusInactive, time.Hour, "100"), reactivate: true, want: stockxgo.RenewalOutcomeSkipped},
+		{name: "inactive without expiry", listing: renewalListing(stockxgo.ListingStatusInactive, 0, "100"), reactivate: true, want: stockxgo.RenewalOutcomeSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := stockxgo.NewRenewalScheduler(client,
+				stockxgo.WithRenewalWindow(72*time.Hour),
+				stockxgo.WithRenewalExtension(30*24*time.Hour),
+				stockxgo.WithRenewalReactivateExpired(tt.reactivate),
+				stockxgo.WithRenewalDryRun(true),
+			)
+
+			report, err := scheduler.Renew(context.Background(), []stockxgo.Listing{tt.listing})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			renewal := report.Renewals[0]
+			if renewal.Outcome != tt.want {
+				t.Errorf("outcome = %s (%s), want %s", renewal.Outcome, renewal.Reason, tt.want)
+			}
+			if tt.want == stockxgo.RenewalOutcomeSkipped && (renewal.Reason == "" || !renewal.NewExpiresAt.IsZero()) {
+				t.Errorf("skipped renewal has reason %q and new expiry %s", renewal.Reason, renewal.NewExpiresAt)
+			}
+		})
+	}
+
+	if requests := srv.Requests(); len(requests) > 0 {
+		t.Errorf("dry runs sent %d requests", len(requests))
+	}
+}
+
+func TestRenewalReactivatesExpired(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	expired := srv.AddListing(renewalListing(stockxgo.ListingStatusInactive, -time.Hour, "100"))
+	client := srv.Client()
+	defer client.Close()
+
+	scheduler := stockxgo.NewRenewalScheduler(client, stockxgo.WithRenewalReactivateExpired(true))
+	report, err := scheduler.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renewed := report.ByOutcome(stockxgo.RenewalOutcomeRenewed)
+	if len(renewed) != 1 || renewed[0].ListingID != expired.ListingID || renewed[0].OperationID == "" {
+		t.Fatalf("renewed = %+v, report = %+v", renewed, report.Renewals)
+	}
+
+	srv.AssertRequested(t, "PUT", "/v2/selling/listings/"+expired.ListingID+"/activate")
+	stored, _ := srv.Listing(expired.ListingID)
+	if stored.Status != stockxgo.ListingStatusActive || !stored.Ask.AskExpiresAt.Equal(renewed[0].NewExpiresAt) || !stored.Amount.Equal(usd("100")) {
+		t.Errorf("listing after reactivation = %s, expires %s, amount %s", stored.Status, stored.Ask.AskExpiresAt, stored.Amount)
+	}
+}
+
+func TestRenewalQueuedOperations(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AsyncOperations = true
+	listing := srv.AddListing(renewalListing(stockxgo.ListingStatusActive, time.Hour, "100"))
+	client := srv.Client()
+	defer client.Close()
+
+	report, err := stockxgo.NewRenewalScheduler(client).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued := report.ByOutcome(stockxgo.RenewalOutcomeQueued); len(queued) != 1 || queued[0].OperationID == "" {
+		t.Errorf("without waiting, report = %+v; want one QUEUED renewal with an operation ID", report.Renewals)
+	}
+
+	// The first run already moved the expiry out, so reach further this time.
+	scheduler := stockxgo.NewRenewalScheduler(client,
+		stockxgo.WithRenewalWait(true),
+		stockxgo.WithRenewalWindow(60*24*time.Hour),
+		stockxgo.WithRenewalExtension(45*24*time.Hour),
+	)
+	report, err = scheduler.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed := report.ByOutcome(stockxgo.RenewalOutcomeRenewed)
+	if len(renewed) != 1 {
+		t.Fatalf("with waiting, report = %+v; want one RENEWED renewal", report.Renewals)
+	}
+	srv.AssertRequested(t, "GET", "/v2/selling/listings/"+listing.ListingID+"/operations/"+renewed[0].OperationID)
+}
@@ -0,0 +1,416 @@
+package stockxgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// RepricingStrategy decides the price of an ask from the market data of its
+// variant. Returning the listing's current amount keeps the price.
+type RepricingStrategy interface {
+	Reprice(listing Listing, market MarketData) (Money, error)
+}
+
+// RepricingStrategyFunc adapts a function to a RepricingStrategy.
+type RepricingStrategyFunc func(listing Listing, market MarketData) (Money, error)
+
+func (f RepricingStrategyFunc) Reprice(listing Listing, market MarketData) (Money, error) {
+	return f(listing, market)
+}
+
+// MatchLowestAsk prices every ask at the current lowest ask.
+func MatchLowestAsk() RepricingStrategy {
+	return RepricingStrategyFunc(func(listing Listing, market MarketData) (Money, error) {
+		if !market.LowestAskAmount.IsSet() {
+			return listing.Amount, nil
+		}
+
+		return market.LowestAskAmount, nil
+	})
+}
+
+// UndercutLowestAsk prices every ask the amount by below the current lowest
+// ask. An ask that is already at or below the lowest ask keeps its price, so
+// the repricer does not keep undercutting its own listings.
+func UndercutLowestAsk(by Money) RepricingStrategy {
+	return RepricingStrategyFunc(func(listing Listing, market MarketData) (Money, error) {
+		if !market.LowestAskAmount.IsSet() {
+			return listing.Amount, nil
+		}
+
+		c, err := listing.Amount.Cmp(market.LowestAskAmount)
+		if err != nil || c <= 0 {
+			return listing.Amount, err
+		}
+
+		return market.LowestAskAmount.Sub(by)
+	})
+}
+
+// TargetSellFaster prices every ask at the SellFasterAmount StockX suggests.
+func TargetSellFaster() RepricingStrategy {
+	return RepricingStrategyFunc(func(listing Listing, market MarketData) (Money, error) {
+		if !market.SellFasterAmount.IsSet() {
+			return listing.Amount, nil
+		}
+
+		return market.SellFasterAmount, nil
+	})
+}
+
+// WithPriceFloors keeps the prices chosen by strategy at or above a floor
+// per variant ID. Variants without a floor are not limited.
+func WithPriceFloors(strategy RepricingStrategy, floors map[string]Money) RepricingStrategy {
+	return RepricingStrategyFunc(func(listing Listing, market MarketData) (Money, error) {
+		amount, err := strategy.Reprice(listing, market)
+		if err != nil {
+			return Money{}, err
+		}
+
+		floor, ok := floors[listing.Variant.VariantID]
+		if !ok {
+			return amount, nil
+		}
+
+		c, err := amount.Cmp(floor)
+		if err != nil {
+			return Money{}, err
+		}
+		if c < 0 {
+			return floor.WithCurrency(amount.Currency()), nil
+		}
+
+		return amount, nil
+	})
+}
+
+// RepriceOutcome is what the repricer did with a listing
+type RepriceOutcome string
+
+const (
+	// RepriceOutcomePending is written to the audit log before a price move
+	// is sent, so a move is on record even if its outcome cannot be.
+	RepriceOutcomePending   RepriceOutcome = "PENDING"
+	RepriceOutcomeApplied   RepriceOutcome = "APPLIED"
+	RepriceOutcomeDryRun    RepriceOutcome = "DRY_RUN"
+	RepriceOutcomeUnchanged RepriceOutcome = "UNCHANGED"
+	RepriceOutcomeCapped    RepriceOutcome = "CAPPED"
+	RepriceOutcomeSkipped   RepriceOutcome = "SKIPPED"
+	RepriceOutcomeFailed    RepriceOutcome = "FAILED"
+)
+
+// PriceChange records the repricing decision for one listing.
+type PriceChange struct {
+	Time         time.Time      `json:"time"`
+	ListingID    string         `json:"listingId"`
+	ProductID    string         `json:"productId"`
+	VariantID    string         `json:"variantId"`
+	CurrencyCode string         `json:"currencyCode"`
+	OldAmount    Money          `json:"oldAmount"`
+	NewAmount    Money          `json:"newAmount"`
+	Outcome      RepriceOutcome `json:"outcome"`
+	// Reason explains skipped, capped and failed listings.
+	Reason string `json:"reason,omitempty"`
+	// OperationID is the listing operation queued by an applied change.
+	OperationID string `json:"operationId,omitempty"`
+}
+
+// RepriceAuditLog records every price move the repricer applies, proposes in
+// a dry run or fails to apply. A move that is sent to StockX is recorded
+// twice: as PENDING before it is sent and with its outcome after.
+type RepriceAuditLog interface {
+	Record(ctx context.Context, change PriceChange) error
+}
+
+// JSONLAuditLog writes price moves to w as JSON lines.
+type JSONLAuditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONLAuditLog(w io.Writer) *JSONLAuditLog {
+	return &JSONLAuditLog{enc: json.NewEncoder(w)}
+}
+
+func (l *JSONLAuditLog) Record(ctx context.Context, change PriceChange) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.enc.Encode(change)
+}
+
+// RepriceReport lists the decision taken for every listing of a cycle.
+type RepriceReport struct {
+	Changes []PriceChange
+}
+
+// ByOutcome returns the changes with the given outcome.
+func (r RepriceReport) ByOutcome(outcome RepriceOutcome) []PriceChange {
+	var changes []PriceChange
+	for _, change := range r.Changes {
+		if change.Outcome == outcome {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// WriteTo writes the report as a table, which is the dry-run output of the
+// repricer.
+func (r RepriceReport) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	tw := tabwriter.NewWriter(cw, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "LISTING\tVARIANT\tOLD\tNEW\tCURRENCY\tOUTCOME\tREASON")
+	for _, c := range r.Changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.ListingID, c.VariantID, c.OldAmount, c.NewAmount, c.CurrencyCode, c.Outcome, c.Reason)
+	}
+
+	err := tw.Flush()
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Repricer moves the price of active asks according to a strategy. Each
+// call to Run is one cycle.
+type Repricer struct {
+	client     StockXClient
+	strategy   RepricingStrategy
+	dryRun     bool
+	maxChanges int
+	maxStep    Money
+	audit      RepriceAuditLog
+}
+
+type RepricerOption func(*Repricer)
+
+// WithRepricerDryRun makes the repricer compute and report new prices
+// without updating any listing.
+func WithRepricerDryRun(dryRun bool) RepricerOption {
+	return func(r *Repricer) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithRepricerMaxChanges caps the number of listings repriced per cycle. The
+// listings over the cap are reported as CAPPED. Zero means no cap.
+func WithRepricerMaxChanges(maxChanges int) RepricerOption {
+	return func(r *Repricer) {
+		r.maxChanges = maxChanges
+	}
+}
+
+// WithRepricerMaxStep limits how far a price may move in one cycle. Larger
+// moves are cut to maxStep in the direction of the target.
+func WithRepricerMaxStep(maxStep Money) RepricerOption {
+	return func(r *Repricer) {
+		r.maxStep = maxStep.Abs()
+	}
+}
+
+// WithRepricerAuditLog records every price move to log.
+func WithRepricerAuditLog(log RepriceAuditLog) RepricerOption {
+	return func(r *Repricer) {
+		r.audit = log
+	}
+}
+
+func NewRepricer(client StockXClient, strategy RepricingStrategy, opts ...RepricerOption) *Repricer {
+	r := &Repricer{
+		client:   client,
+		strategy: strategy,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// RunActive reprices every active listing of the account.
+func (r *Repricer) RunActive(ctx context.Context) (RepriceReport, error) {
+	var listings []Listing
+	for listing, err := range r.client.ListingsIter(ctx, WithGetAllListingsListingStatuses([]ListingStatus{ListingStatusActive})) {
+		if err != nil {
+			return RepriceReport{}, err
+		}
+		listings = append(listings, listing)
+	}
+
+	return r.Run(ctx, listings)
+}
+
+// Run reprices the given listings in order. Listings that are not active
+// are skipped. Market data is fetched once per variant and currency. A
+// failed update is reported and does not stop the cycle; Run only returns
+// an error when the context is cancelled or the audit log cannot be
+// written, since prices must not move without an audit trail. When the
+// intended move cannot be recorded, the listing is left untouched.
+func (r *Repricer) Run(ctx context.Context, listings []Listing) (RepriceReport, error) {
+	var report RepriceReport
+	market := make(map[MarketDataKey]MarketData)
+	moved := 0
+
+	for _, listing := range listings {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		change := PriceChange{
+			Time:         time.Now(),
+			ListingID:    listing.ListingID,
+			ProductID:    listing.Product.ProductID,
+			VariantID:    listing.Variant.VariantID,
+			CurrencyCode: listing.CurrencyCode,
+			OldAmount:    listing.Amount,
+			NewAmount:    listing.Amount,
+		}
+
+		r.decide(ctx, listing, market, &change)
+
+		if change.Outcome != "" {
+			report.Changes = append(report.Changes, change)
+			continue
+		}
+
+		if r.maxChanges > 0 && moved >= r.maxChanges {
+			change.Outcome = RepriceOutcomeCapped
+			change.Reason = fmt.Sprintf("cycle cap of %d changes reached", r.maxChanges)
+			report.Changes = append(report.Changes, change)
+			continue
+		}
+
+		if !r.dryRun && r.audit != nil {
+			intent := change
+			intent.Outcome = RepriceOutcomePending
+			if err := r.audit.Record(ctx, intent); err != nil {
+				return report, fmt.Errorf("failed to write repricing audit log: %w", err)
+			}
+		}
+
+		moved++
+		r.apply(ctx, listing, &change)
+		report.Changes = append(report.Changes, change)
+
+		if r.audit != nil {
+			if err := r.audit.Record(ctx, change); err != nil {
+				return report, fmt.Errorf("failed to write repricing audit log: %w", err)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// decide fills in the new amount of change. It leaves the outcome empty when
+// the price should move.
+func (r *Repricer) decide(ctx context.Context, listing Listing, market map[MarketDataKey]MarketData, change *PriceChange) {
+	if listing.Status != ListingStatusActive {
+		change.Outcome = RepriceOutcomeSkipped
+		change.Reason = fmt.Sprintf("listing is %s", listing.Status)
+		return
+	}
+
+	key := MarketDataKey{listing.Product.ProductID, listing.Variant.VariantID, listing.CurrencyCode}
+	data, ok := market[key]
+	if !ok {
+		var err error
+		data, err = r.client.GetProductMarketDataForVariantContext(ctx, key.ProductID, key.VariantID, key.CurrencyCode)
+		if err != nil {
+			change.Outcome = RepriceOutcomeFailed
+			change.Reason = fmt.Sprintf("failed to fetch market data: %s", err)
+			return
+		}
+		market[key] = data
+	}
+
+	target, err := r.strategy.Reprice(listing, data)
+	if err != nil {
+		change.Outcome = RepriceOutcomeFailed
+		change.Reason = err.Error()
+		return
+	}
+
+	target, err = r.step(listing.Amount, target)
+	if err != nil {
+		change.Outcome = RepriceOutcomeFailed
+		change.Reason = err.Error()
+		return
+	}
+
+	if target.Sign() <= 0 {
+		change.Outcome = RepriceOutcomeSkipped
+		change.Reason = fmt.Sprintf("strategy chose non-positive amount %s", target)
+		return
+	}
+
+	change.NewAmount = target.WithCurrency(listing.CurrencyCode)
+	if change.NewAmount.Equal(listing.Amount) {
+		change.Outcome = RepriceOutcomeUnchanged
+	}
+}
+
+// step cuts a move from current to target down to the maximum step.
+func (r *Repricer) step(current, target Money) (Money, error) {
+	if !r.maxStep.IsSet() || r.maxStep.IsZero() {
+		return target, nil
+	}
+
+	diff, err := target.Sub(current)
+	if err != nil {
+		return Money{}, err
+	}
+
+	c, err := diff.Abs().Cmp(r.maxStep)
+	if err != nil || c <= 0 {
+		return target, err
+	}
+
+	if diff.Sign() < 0 {
+		return current.Sub(r.maxStep)
+	}
+
+	return current.Add(r.maxStep)
+}
+
+func (r *Repricer) apply(ctx context.Context, listing Listing, change *PriceChange) {
+	if r.dryRun {
+		change.Outcome = RepriceOutcomeDryRun
+		return
+	}
+
+	payload := UpdateListingPayload{
+		Amount:       change.NewAmount,
+		CurrencyCode: listing.CurrencyCode,
+	}
+	if !listing.Ask.AskExpiresAt.IsZero() {
+		payload.ExpiresAt = listing.Ask.AskExpiresAt.Format(time.RFC3339)
+	}
+
+	response, err := r.client.UpdateListingContext(ctx, listing.ListingID, payload)
+	if err != nil {
+		change.Outcome = RepriceOutcomeFailed
+		change.Reason = err.Error()
+		return
+	}
+
+	change.Outcome = RepriceOutcomeApplied
+	change.OperationID = response.OperationID
+}
@@ -0,0 +1,196 @@
+package stockxgo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+// auditLog keeps the recorded changes and fails once failAt records have
+// been written. A negative failAt never fails.
+type auditLog struct {
+	changes []stockxgo.PriceChange
+	failAt  int
+}
+
+func (l *auditLog) Record(ctx context.Context, change stockxgo.PriceChange) error {
+	if l.failAt >= 0 && len(l.changes) >= l.failAt {
+		return errors.New("disk full")
+	}
+	l.changes = append(l.changes, change)
+	return nil
+}
+
+func usd(amount string) stockxgo.Money {
+	return stockxgo.MustParseMoney(amount, "USD")
+}
+
+func newRepricerServer(t *testing.T, prices ...string) (*stockxtest.Server, []stockxgo.Listing) {
+	t.Helper()
+
+	srv := stockxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"}, stockxgo.ProductVariant{ProductID: "product-1", VariantID: "variant-1"})
+	srv.SetMarketData(stockxgo.MarketData{
+		ProductID:        "product-1",
+		VariantID:        "variant-1",
+		CurrencyCode:     "USD",
+		LowestAskAmount:  usd("100"),
+		SellFasterAmount: usd("95"),
+	})
+
+	var listings []stockxgo.Listing
+	for _, price := range prices {
+		listing := stockxgo.Listing{Status: stockxgo.ListingStatusActive, Amount: usd(price), CurrencyCode: "USD"}
+		listing.Product.ProductID = "product-1"
+		listing.Variant.VariantID = "variant-1"
+		listings = append(listings, srv.AddListing(listing))
+	}
+
+	return srv, listings
+}
+
+func TestRepricingStrategies(t *testing.T) {
+	market := stockxgo.MarketData{LowestAskAmount: usd("100"), SellFasterAmount: usd("95")}
+	floored := stockxgo.WithPriceFloors(stockxgo.UndercutLowestAsk(usd("10")), map[string]stockxgo.Money{"variant-1": usd("95")})
+
+	tests := []struct {
+		name     string
+		strategy stockxgo.RepricingStrategy
+		current  string
+		variant  string
+		market   stockxgo.MarketData
+		want     string
+	}{
+		{name: "match", strategy: stockxgo.MatchLowestAsk(), current: "120", market: market, want: "100"},
+		{name: "match without market", strategy: stockxgo.MatchLowestAsk(), current: "120", want: "120"},
+		{name: "undercut", strategy: stockxgo.UndercutLowestAsk(usd("1")), current: "120", market: market, want: "99"},
+		{name: "undercut own lowest ask", strategy: stockxgo.UndercutLowestAsk(usd("1")), current: "100", market: market, want: "100"},
+		{name: "sell faster", strategy: stockxgo.TargetSellFaster(), current: "120", market: market, want: "95"},
+		{name: "floor", strategy: floored, current: "120", variant: "variant-1", market: market, want: "95"},
+		{name: "no floor for variant", strategy: floored, current: "120", variant: "variant-2", market: market, want: "90"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listing := stockxgo.Listing{Amount: usd(tt.current)}
+			listing.Variant.VariantID = tt.variant
+
+			got, err := tt.strategy.Reprice(listing, tt.market)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(usd(tt.want)) {
+				t.Errorf("Reprice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepricerRun(t *testing.T) {
+	srv, listings := newRepricerServer(t, "130", "104", "100", "125")
+	client := srv.Client()
+	defer client.Close()
+
+	inactive := stockxgo.Listing{ListingID: "listing-inactive", Status: stockxgo.ListingStatusInactive}
+
+	audit := &auditLog{failAt: -1}
+	repricer := stockxgo.NewRepricer(client, stockxgo.MatchLowestAsk(),
+		stockxgo.WithRepricerMaxStep(usd("10")),
+		stockxgo.WithRepricerMaxChanges(2),
+		stockxgo.WithRepricerAuditLog(audit),
+	)
+
+	report, err := repricer.Run(context.Background(), append(listings, inactive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		outcome stockxgo.RepriceOutcome
+		amount  string
+	}{
+		{stockxgo.RepriceOutcomeApplied, "120"},
+		{stockxgo.RepriceOutcomeApplied, "100"},
+		{stockxgo.RepriceOutcomeUnchanged, "100"},
+		{stockxgo.RepriceOutcomeCapped, "115"},
+		{stockxgo.RepriceOutcomeSkipped, ""},
+	}
+	if len(report.Changes) != len(want) {
+		t.Fatalf("report has %d changes, want %d", len(report.Changes), len(want))
+	}
+	for i, w := range want {
+		change := report.Changes[i]
+		if change.Outcome != w.outcome || (w.amount != "" && !change.NewAmount.Equal(usd(w.amount))) {
+			t.Errorf("change %d = %s %s, want %s %s", i, change.Outcome, change.NewAmount, w.outcome, w.amount)
+		}
+	}
+
+	// Only applied moves reach StockX, and the listings hold the new prices.
+	for i, price := range []string{"120", "100"} {
+		stored, _ := srv.Listing(listings[i].ListingID)
+		if !stored.Amount.Equal(usd(price)) {
+			t.Errorf("listing %d amount = %s, want %s", i, stored.Amount, price)
+		}
+	}
+	srv.AssertNotRequested(t, "PATCH", "/v2/selling/listings/"+listings[3].ListingID)
+
+	// Every applied move is recorded before it is sent and after.
+	var outcomes []stockxgo.RepriceOutcome
+	for _, change := range audit.changes {
+		outcomes = append(outcomes, change.Outcome)
+	}
+	if len(outcomes) != 4 || outcomes[0] != stockxgo.RepriceOutcomePending || outcomes[1] != stockxgo.RepriceOutcomeApplied ||
+		outcomes[2] != stockxgo.RepriceOutcomePending || outcomes[3] != stockxgo.RepriceOutcomeApplied {
+		t.Errorf("audit log = %v, want PENDING, APPLIED twice", outcomes)
+	}
+}
+
+func TestRepricerDryRun(t *testing.T) {
+	srv, listings := newRepricerServer(t, "130")
+	client := srv.Client()
+	defer client.Close()
+
+	audit := &auditLog{failAt: -1}
+	repricer := stockxgo.NewRepricer(client, stockxgo.MatchLowestAsk(),
+		stockxgo.WithRepricerDryRun(true),
+		stockxgo.WithRepricerAuditLog(audit),
+	)
+
+	report, err := repricer.Run(context.Background(), listings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dry := report.ByOutcome(stockxgo.RepriceOutcomeDryRun); len(dry) != 1 || !dry[0].NewAmount.Equal(usd("100")) {
+		t.Errorf("dry run changes = %+v", dry)
+	}
+	if len(audit.changes) != 1 || audit.changes[0].Outcome != stockxgo.RepriceOutcomeDryRun {
+		t.Errorf("audit log = %+v, want one DRY_RUN entry", audit.changes)
+	}
+	srv.AssertNotRequested(t, "PATCH", "/v2/selling/listings/"+listings[0].ListingID)
+}
+
+func TestRepricerStopsWhenAuditLogFails(t *testing.T) {
+	srv, listings := newRepricerServer(t, "130", "120")
+	client := srv.Client()
+	defer client.Close()
+
+	// The first move is fully recorded; the intent of the second is not.
+	audit := &auditLog{failAt: 2}
+	repricer := stockxgo.NewRepricer(client, stockxgo.MatchLowestAsk(), stockxgo.WithRepricerAuditLog(audit))
+
+	if _, err := repricer.Run(context.Background(), listings); err == nil {
+		t.Fatal("Run succeeded with an unwritable audit log")
+	}
+
+	srv.AssertRequestCount(t, "PATCH", "/v2/selling/listings/"+listings[0].ListingID, 1)
+	srv.AssertNotRequested(t, "PATCH", "/v2/selling/listings/"+listings[1].ListingID)
+	if stored, _ := srv.Listing(listings[1].ListingID); !stored.Amount.Equal(usd("120")) {
+		t.Errorf("unaudited listing moved to %s", stored.Amount)
+	}
+}
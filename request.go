@@ -0,0 +1,113 @@
+package stockxgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// do sends an authorized API request. When StockX rejects the access token
+// with a 401, the token is refreshed and the request is replayed once.
+func (s *stockXClient) do(req *http.Request) (*http.Response, error) {
+	token, err := s.accessToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	s.authorize(req, token)
+
+	resp, err := s.send(req)
+	if err == nil || !errors.Is(err, ErrUnauthorized) || s.GetRefreshToken() == "" {
+		return resp, err
+	}
+
+	// Another request may have refreshed the token in the meantime.
+	if s.GetAccessToken() == token {
+		if refreshErr := s.refresh(req.Context()); refreshErr != nil {
+			return nil, err
+		}
+	}
+
+	if rewindErr := rewindBody(req); rewindErr != nil {
+		return nil, err
+	}
+
+	s.authorize(req, s.GetAccessToken())
+
+	return s.send(req)
+}
+
+func (s *stockXClient) authorize(req *http.Request, token string) {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("x-api-key", s.apiKey)
+}
+
+// send sends req, retrying it according to the client's retry policy and
+// waiting on the rate limiter of its endpoint family before each attempt.
+// Responses without a 2xx status are turned into an *APIError; on success the
+// caller owns the returned response body.
+func (s *stockXClient) send(req *http.Request) (*http.Response, error) {
+	if s.lifecycle.Err() != nil {
+		return nil, ErrClientClosed
+	}
+
+	policy := s.retryPolicy
+	family := endpointFamily(req.URL.Path)
+
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.limiter.wait(req.Context(), family); err != nil {
+			return nil, err
+		}
+
+		resp, err := s.client.Do(req)
+		if err == nil {
+			s.limiter.observe(family, resp)
+			if err = checkResponse(resp); err == nil {
+				return resp, nil
+			}
+			resp.Body.Close()
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(req, resp, err) {
+			return nil, err
+		}
+
+		delay, ok := policy.backoff(attempt, resp)
+		if !ok {
+			return nil, err
+		}
+
+		if sleepErr := sleepContext(req.Context(), delay); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewindBody resets the request body so the request can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	if req.GetBody == nil {
+		return errors.New("request body cannot be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,209 @@
+package stockxgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry. It doubles on every
+	// subsequent attempt up to MaxBackoff.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed delay. A Retry-After header asking for a
+	// longer wait stops retrying and returns the error instead.
+	MaxBackoff time.Duration
+	// Jitter randomises each delay by up to the given fraction (0 to 1).
+	Jitter float64
+	// RetryStatuses lists the HTTP status codes that are retried.
+	RetryStatuses []int
+	// RetryError reports whether a transport error is retried. Context
+	// cancellation is never retried.
+	RetryError func(error) bool
+	// RetryNonIdempotent allows retrying POST, PUT and PATCH requests on any
+	// retryable status or error. When false they are only retried when the
+	// request is known not to have been processed (429 responses and failed
+	// connection attempts) or when the context was marked with MarkIdempotent.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by new clients: three attempts
+// with exponential backoff for rate limits, server errors and transient
+// network errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryError: IsTransientError,
+	}
+}
+
+// NoRetryPolicy returns a policy that sends every request exactly once.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// IsTransientError reports whether err is a network error that is usually
+// resolved by trying again, such as a timeout or a reset connection.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+type idempotentKey struct{}
+
+// MarkIdempotent returns a context that tells the client the request made
+// with it is safe to retry, even if its method is POST, PUT or PATCH.
+func MarkIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	// PUT is left out: StockX uses it for mutations such as batch updates
+	// that queue new work on every call.
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// notProcessed reports whether the failed attempt is known not to have
+// reached StockX's business logic, making it safe to retry any method.
+func notProcessed(resp *http.Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if resp != nil {
+		if !slices.Contains(p.RetryStatuses, resp.StatusCode) {
+			return false
+		}
+	} else if p.RetryError == nil || !p.RetryError(err) {
+		return false
+	}
+
+	return p.RetryNonIdempotent || isIdempotent(req) || notProcessed(resp, err)
+}
+
+// backoff returns the delay before the given retry attempt (starting at 1).
+// The second result is false when the server asked to wait longer than
+// MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	delay := float64(p.BaseBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay), true
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package stockxgo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestShouldRetry(t *testing.T) {
+	resetErr := &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	dialErr := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name          string
+		method        string
+		status        int
+		err           error
+		idempotent    bool
+		nonIdempotent bool
+		want          bool
+	}{
+		{name: "GET 503", method: "GET", status: 503, want: true},
+		{name: "GET 400", method: "GET", status: 400, want: false},
+		{name: "GET reset", method: "GET", err: resetErr, want: true},
+		{name: "DELETE 500", method: "DELETE", status: 500, want: true},
+		{name: "PUT 503", method: "PUT", status: 503, want: false},
+		{name: "PUT reset", method: "PUT", err: resetErr, want: false},
+		{name: "PUT 429", method: "PUT", status: 429, want: true},
+		{name: "PUT dial error", method: "PUT", err: dialErr, want: true},
+		{name: "PUT 503 marked idempotent", method: "PUT", status: 503, idempotent: true, want: true},
+		{name: "POST 502", method: "POST", status: 502, want: false},
+		{name: "POST 429", method: "POST", status: 429, want: true},
+		{name: "PATCH 503 non-idempotent allowed", method: "PATCH", status: 503, nonIdempotent: true, want: true},
+		{name: "PATCH reset marked idempotent", method: "PATCH", err: resetErr, idempotent: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultRetryPolicy()
+			policy.RetryNonIdempotent = tt.nonIdempotent
+
+			ctx := context.Background()
+			if tt.idempotent {
+				ctx = MarkIdempotent(ctx)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, "https://api.stockx.com/v2/selling/listings", bytes.NewReader([]byte("{}")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resp *http.Response
+			if tt.status != 0 {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			}
+
+			if got := policy.shouldRetry(req, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetryNeverRetries(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.RetryNonIdempotent = true
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.stockx.com/v2/catalog/search", nil)
+	if policy.shouldRetry(req, unavailable, nil) {
+		t.Error("retried a request whose context is cancelled")
+	}
+
+	// A body that cannot be rewound cannot be sent again.
+	req, _ = http.NewRequest("POST", "https://api.stockx.com/v2/selling/listings", io.MultiReader(strings.NewReader("{}")))
+	if policy.shouldRetry(req, unavailable, nil) {
+		t.Error("retried a request whose body cannot be replayed")
+	}
+}
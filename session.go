@@ -0,0 +1,161 @@
+package stockxgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// refreshSkew is how long before expiry the access token is refreshed.
+	refreshSkew = time.Minute
+	// minRefreshInterval keeps the refresh loop from spinning when the
+	// session has no known expiry or a refresh keeps failing.
+	minRefreshInterval = 30 * time.Second
+)
+
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+func expiresAt(expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(expiresIn) * time.Second)
+}
+
+// withExpiry fills in ExpiresAt from ExpiresIn when only the latter is known,
+// counting from now since the time the token was issued is not recorded.
+func (s Session) withExpiry() Session {
+	if s.ExpiresAt.IsZero() {
+		s.ExpiresAt = expiresAt(s.ExpiresIn)
+	}
+	return s
+}
+
+// expiresWithin reports whether the session has a known expiry that falls
+// within d from now.
+func (s Session) expiresWithin(d time.Duration) bool {
+	return !s.ExpiresAt.IsZero() && time.Until(s.ExpiresAt) < d
+}
+
+// GetSession returns a copy of the current session.
+func (s *stockXClient) GetSession() Session {
+	s.sessionMu.RLock()
+	defer s.sessionMu.RUnlock()
+	return s.session
+}
+
+func (s *stockXClient) setSession(session Session) {
+	s.sessionMu.Lock()
+	s.session = session
+	s.sessionMu.Unlock()
+}
+
+// refresh refreshes the access token, sharing one in-flight request between
+// concurrent callers. The request is not tied to any single caller's context,
+// so one caller giving up does not fail the refresh for the others; it is
+// cancelled when the client is closed, and Close waits for it.
+func (s *stockXClient) refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	if s.lifecycle.Err() != nil {
+		s.refreshMu.Unlock()
+		return ErrClientClosed
+	}
+
+	call := s.refreshCall
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		s.refreshCall = call
+
+		refreshCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		stop := context.AfterFunc(s.lifecycle, cancel)
+
+		s.loopWG.Add(1)
+		go func() {
+			defer s.loopWG.Done()
+			defer cancel()
+			defer stop()
+
+			call.err = s.refreshToken(refreshCtx)
+			if call.err == nil {
+				// The new token may carry the expiry the old one lacked.
+				s.startRefreshLoop()
+			}
+
+			s.refreshMu.Lock()
+			s.refreshCall = nil
+			s.refreshMu.Unlock()
+
+			close(call.done)
+		}()
+	}
+	s.refreshMu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// accessToken returns the token to authorize a request with, refreshing it
+// first when it is about to expire.
+func (s *stockXClient) accessToken(ctx context.Context) (string, error) {
+	session := s.GetSession()
+	if session.RefreshToken == "" || !session.expiresWithin(refreshSkew) {
+		return session.AccessToken, nil
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		// A token that has not expired yet is still worth trying.
+		if session.expiresWithin(0) {
+			return "", err
+		}
+		return session.AccessToken, nil
+	}
+
+	return s.GetSession().AccessToken, nil
+}
+
+func (s *stockXClient) saveSession(ctx context.Context) error {
+	if s.tokenStore == nil {
+		return nil
+	}
+
+	if err := s.tokenStore.Save(ctx, s.GetSession()); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
+
+// adoptStoredSession switches to the stored session when another process
+// has already refreshed it, instead of refreshing again. It reports whether
+// the stored session was adopted.
+func (s *stockXClient) adoptStoredSession(ctx context.Context) (bool, error) {
+	if s.tokenStore == nil {
+		return false, nil
+	}
+
+	stored, err := s.tokenStore.Load(ctx)
+	if errors.Is(err, ErrNoSession) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	current := s.GetSession()
+	if stored.AccessToken == "" || stored.AccessToken == current.AccessToken ||
+		!stored.ExpiresAt.After(current.ExpiresAt) || stored.expiresWithin(refreshSkew) {
+		return false, nil
+	}
+
+	s.setSession(stored)
+	return true, nil
+}
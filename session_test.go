@@ -0,0 +1,85 @@
+package stockxgo_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+func TestUnauthorizedRefreshesOnceAndReplays(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+	client := srv.Client()
+	defer client.Close()
+
+	// Hold the refresh open so every rejected request joins the same one.
+	srv.ExpireTokens()
+	srv.Inject(stockxtest.Failure{Method: "POST", Path: "/oauth/token", Delay: 200 * time.Millisecond, Times: 1})
+
+	const requests = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetSingleProduct("product-1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetSingleProduct: %v", err)
+		}
+	}
+
+	srv.AssertRequestCount(t, "POST", "/oauth/token", 1)
+	srv.AssertRequestCount(t, "GET", "/v2/catalog/products/product-1", 2*requests)
+}
+
+func TestSessionWithoutExpiresAt(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+
+	access, refresh := srv.IssueTokens()
+	client := srv.Client(stockxgo.WithSession(stockxgo.Session{AccessToken: access, RefreshToken: refresh, ExpiresIn: 3600}))
+	defer client.Close()
+
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	if until := time.Until(client.GetSession().ExpiresAt); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("ExpiresAt derived from ExpiresIn is %s away, want about an hour", until)
+	}
+
+	// Without any expiry the token is refreshed only when it is rejected.
+	access, refresh = srv.IssueTokens()
+	unknown := srv.Client(stockxgo.WithSession(stockxgo.Session{AccessToken: access, RefreshToken: refresh}))
+	defer unknown.Close()
+
+	if err := unknown.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	if !unknown.GetSession().ExpiresAt.IsZero() {
+		t.Errorf("ExpiresAt = %s, want zero", unknown.GetSession().ExpiresAt)
+	}
+
+	srv.ExpireTokens()
+	if _, err := unknown.GetSingleProduct("product-1"); err != nil {
+		t.Fatalf("GetSingleProduct: %v", err)
+	}
+	srv.AssertRequestCount(t, "POST", "/oauth/token", 1)
+	if unknown.GetSession().ExpiresAt.IsZero() {
+		t.Error("ExpiresAt is still zero after a refresh that reported expires_in")
+	}
+}
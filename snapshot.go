@@ -0,0 +1,182 @@
+package stockxgo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Snapshot is market data as it was at a point in time.
+type Snapshot struct {
+	Time       time.Time  `json:"time"`
+	MarketData MarketData `json:"marketData"`
+}
+
+// SnapshotStore keeps the market data history. A client created with
+// WithSnapshotStore appends every market data response it receives.
+type SnapshotStore interface {
+	Append(ctx context.Context, snapshots ...Snapshot) error
+	// Series returns the snapshots of one variant and currency taken at or
+	// after from and before to, oldest first. A zero from or to leaves that
+	// end of the range open.
+	Series(ctx context.Context, key MarketDataKey, from, to time.Time) ([]Snapshot, error)
+}
+
+// PriceField selects one of the amounts of MarketData.
+type PriceField string
+
+const (
+	PriceFieldLowestAsk     PriceField = "lowestAsk"
+	PriceFieldHighestBid    PriceField = "highestBid"
+	PriceFieldSellFaster    PriceField = "sellFaster"
+	PriceFieldEarnMore      PriceField = "earnMore"
+	PriceFieldFlexLowestAsk PriceField = "flexLowestAsk"
+)
+
+// Of returns the amount the field selects from data.
+func (f PriceField) Of(data MarketData) Money {
+	switch f {
+	case PriceFieldLowestAsk:
+		return data.LowestAskAmount
+	case PriceFieldHighestBid:
+		return data.HighestBidAmount
+	case PriceFieldSellFaster:
+		return data.SellFasterAmount
+	case PriceFieldEarnMore:
+		return data.EarnMoreAmount
+	case PriceFieldFlexLowestAsk:
+		return data.FlexLowestAskAmount
+	}
+
+	return Money{}
+}
+
+// PriceStats summarises one amount over a range of snapshots. Snapshots
+// without the amount are not counted.
+type PriceStats struct {
+	Count int
+	Min   Money
+	Max   Money
+	// Avg is rounded to two more decimal places than the recorded amounts.
+	Avg   Money
+	First time.Time
+	Last  time.Time
+}
+
+// SpreadPoint is the gap between the lowest ask and the highest bid at one
+// point in time.
+type SpreadPoint struct {
+	Time       time.Time
+	LowestAsk  Money
+	HighestBid Money
+	Spread     Money
+}
+
+// RecordMarketData appends market data to store, stamped with the current
+// time.
+func RecordMarketData(ctx context.Context, store SnapshotStore, data ...MarketData) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	snapshots := make([]Snapshot, len(data))
+	for i, d := range data {
+		snapshots[i] = Snapshot{Time: now, MarketData: d}
+	}
+
+	return store.Append(ctx, snapshots...)
+}
+
+// SnapshotPriceStats returns the minimum, maximum and average of field for a
+// variant between from and to.
+func SnapshotPriceStats(ctx context.Context, store SnapshotStore, key MarketDataKey, field PriceField, from, to time.Time) (PriceStats, error) {
+	series, err := store.Series(ctx, key, from, to)
+	if err != nil {
+		return PriceStats{}, err
+	}
+
+	var stats PriceStats
+	var sum Money
+	var scale int32
+	for _, snapshot := range series {
+		amount := field.Of(snapshot.MarketData)
+		if !amount.IsSet() {
+			continue
+		}
+
+		if stats.Count == 0 {
+			stats.Min, stats.Max, stats.First = amount, amount, snapshot.Time
+		}
+		if c, err := amount.Cmp(stats.Min); err != nil {
+			return PriceStats{}, err
+		} else if c < 0 {
+			stats.Min = amount
+		}
+		if c, err := amount.Cmp(stats.Max); err != nil {
+			return PriceStats{}, err
+		} else if c > 0 {
+			stats.Max = amount
+		}
+
+		if sum, err = sum.Add(amount); err != nil {
+			return PriceStats{}, err
+		}
+		scale = max(scale, amount.scale)
+
+		stats.Count++
+		stats.Last = snapshot.Time
+	}
+
+	if stats.Count > 0 {
+		if stats.Avg, err = sum.Div(int64(stats.Count), scale+2); err != nil {
+			return PriceStats{}, err
+		}
+	}
+
+	return stats, nil
+}
+
+// SnapshotSpread returns the spread between the lowest ask and the highest
+// bid of a variant over time. Snapshots missing either amount are left out.
+func SnapshotSpread(ctx context.Context, store SnapshotStore, key MarketDataKey, from, to time.Time) ([]SpreadPoint, error) {
+	series, err := store.Series(ctx, key, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []SpreadPoint
+	for _, snapshot := range series {
+		ask, bid := snapshot.MarketData.LowestAskAmount, snapshot.MarketData.HighestBidAmount
+		if !ask.IsSet() || !bid.IsSet() {
+			continue
+		}
+
+		spread, err := ask.Sub(bid)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, SpreadPoint{
+			Time:       snapshot.Time,
+			LowestAsk:  ask,
+			HighestBid: bid,
+			Spread:     spread,
+		})
+	}
+
+	return points, nil
+}
+
+// recordSnapshots appends fetched market data to the client's snapshot
+// store, if it has one. Failures go to the snapshot error handler rather than
+// to the caller, whose fetch succeeded.
+func (s *stockXClient) recordSnapshots(ctx context.Context, data ...MarketData) {
+	if s.snapshotStore == nil {
+		return
+	}
+
+	if err := RecordMarketData(ctx, s.snapshotStore, data...); err != nil && s.onSnapshotError != nil {
+		s.onSnapshotError(fmt.Errorf("failed to record market data snapshot: %w", err))
+	}
+}
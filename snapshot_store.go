@@ -0,0 +1,147 @@
+package stockxgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// JSONLSnapshotStore appends snapshots to a file, one JSON object per line.
+// Series reads the whole file, so it suits histories of moderate size.
+type JSONLSnapshotStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewJSONLSnapshotStore(path string) *JSONLSnapshotStore {
+	return &JSONLSnapshotStore{path: path}
+}
+
+func (j *JSONLSnapshotStore) Append(ctx context.Context, snapshots ...Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	for _, snapshot := range snapshots {
+		line, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	// A single write keeps a batch from interleaving with other writers.
+	if _, err := file.Write(buf); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func (j *JSONLSnapshotStore) Series(ctx context.Context, key MarketDataKey, from, to time.Time) ([]Snapshot, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var series []Snapshot
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot on line %d of %s: %w", line, j.path, err)
+		}
+
+		if snapshot.MarketData.Key() == key && inRange(snapshot.Time, from, to) {
+			series = append(series, snapshot)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sortSnapshots(series)
+	return series, nil
+}
+
+// MemorySnapshotStore keeps snapshots in memory. The history is lost when the
+// process exits.
+type MemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[MarketDataKey][]Snapshot
+}
+
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: make(map[MarketDataKey][]Snapshot)}
+}
+
+func (m *MemorySnapshotStore) Append(ctx context.Context, snapshots ...Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changed := make(map[MarketDataKey]bool)
+	for _, snapshot := range snapshots {
+		key := snapshot.MarketData.Key()
+		m.snapshots[key] = append(m.snapshots[key], snapshot)
+		changed[key] = true
+	}
+
+	for key := range changed {
+		sortSnapshots(m.snapshots[key])
+	}
+
+	return nil
+}
+
+func (m *MemorySnapshotStore) Series(ctx context.Context, key MarketDataKey, from, to time.Time) ([]Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var series []Snapshot
+	for _, snapshot := range m.snapshots[key] {
+		if inRange(snapshot.Time, from, to) {
+			series = append(series, snapshot)
+		}
+	}
+
+	return series, nil
+}
+
+func sortSnapshots(snapshots []Snapshot) {
+	slices.SortStableFunc(snapshots, func(a, b Snapshot) int {
+		return a.Time.Compare(b.Time)
+	})
+}
+
+// inRange reports whether t is in [from, to), treating zero bounds as open.
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
@@ -0,0 +1,109 @@
+package stockxgo_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+type failingSnapshotStore struct {
+	stockxgo.SnapshotStore
+}
+
+func (failingSnapshotStore) Append(context.Context, ...stockxgo.Snapshot) error {
+	return errors.New("disk full")
+}
+
+func newMarketDataServer(t *testing.T) *stockxtest.Server {
+	t.Helper()
+
+	srv := stockxtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"}, stockxgo.ProductVariant{ProductID: "product-1", VariantID: "variant-1"})
+	return srv
+}
+
+func setMarket(srv *stockxtest.Server, ask, bid string) {
+	srv.SetMarketData(stockxgo.MarketData{
+		ProductID:        "product-1",
+		VariantID:        "variant-1",
+		CurrencyCode:     "USD",
+		LowestAskAmount:  stockxgo.MustParseMoney(ask, "USD"),
+		HighestBidAmount: stockxgo.MustParseMoney(bid, "USD"),
+	})
+}
+
+func TestSnapshotStores(t *testing.T) {
+	stores := map[string]stockxgo.SnapshotStore{
+		"memory": stockxgo.NewMemorySnapshotStore(),
+		"jsonl":  stockxgo.NewJSONLSnapshotStore(filepath.Join(t.TempDir(), "snapshots.jsonl")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			srv := newMarketDataServer(t)
+			client := srv.Client(stockxgo.WithSnapshotStore(store))
+			defer client.Close()
+
+			for _, prices := range [][2]string{{"100", "80"}, {"120.50", "90"}, {"90", "85"}} {
+				setMarket(srv, prices[0], prices[1])
+				if _, err := client.GetProductMarketDataForVariant("product-1", "variant-1", "USD"); err != nil {
+					t.Fatal(err)
+				}
+				// Keep the snapshot timestamps distinct.
+				time.Sleep(time.Millisecond)
+			}
+
+			ctx := context.Background()
+			key := stockxgo.MarketDataKey{ProductID: "product-1", VariantID: "variant-1", CurrencyCode: "USD"}
+
+			series, err := store.Series(ctx, key, time.Time{}, time.Time{})
+			if err != nil || len(series) != 3 {
+				t.Fatalf("Series = %d snapshots, %v; want 3", len(series), err)
+			}
+
+			stats, err := stockxgo.SnapshotPriceStats(ctx, store, key, stockxgo.PriceFieldLowestAsk, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stats.Count != 3 || stats.Min.String() != "90" || stats.Max.String() != "120.50" || stats.Avg.String() != "103.5000" {
+				t.Errorf("stats = %d readings, min %s, max %s, avg %s", stats.Count, stats.Min, stats.Max, stats.Avg)
+			}
+
+			spread, err := stockxgo.SnapshotSpread(ctx, store, key, series[1].Time, time.Time{})
+			if err != nil || len(spread) != 2 || spread[0].Spread.String() != "30.50" || spread[1].Spread.String() != "5" {
+				t.Errorf("spread = %+v, %v", spread, err)
+			}
+		})
+	}
+}
+
+func TestSnapshotFailureDoesNotFailFetch(t *testing.T) {
+	srv := newMarketDataServer(t)
+	setMarket(srv, "100", "80")
+
+	var failures []error
+	client := srv.Client(
+		stockxgo.WithDefaultCurrency("USD"),
+		stockxgo.WithSnapshotStore(failingSnapshotStore{}),
+		stockxgo.WithSnapshotErrorHandler(func(err error) {
+			failures = append(failures, err)
+		}),
+	)
+	defer client.Close()
+
+	result, err := client.GetBulkMarketData(context.Background(), []string{"product-1"})
+	if err != nil || len(result.Data) != 1 || len(result.Errors) != 0 {
+		t.Fatalf("GetBulkMarketData = %d results, %v errors, %v", len(result.Data), result.Errors, err)
+	}
+
+	if len(failures) != 1 {
+		t.Errorf("snapshot error handler called %d times, want 1", len(failures))
+	}
+}
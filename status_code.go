@@ -1,28 +1,146 @@
 package stockxgo
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 )
 
 var (
-	ErrUnauthorized  = errors.New("unauthorized")
-	ErrBadRequest    = errors.New("bad request")
-	ErrInternal      = errors.New("internal server error")
-	ErrUnknownStatus = errors.New("unknown status code: %v")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrBadRequest          = errors.New("bad request")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrUnprocessableEntity = errors.New("unprocessable entity")
+	ErrTooManyRequests     = errors.New("too many requests")
+	ErrInternal            = errors.New("internal server error")
+	ErrUnknownStatus       = errors.New("unknown status code")
+	ErrClientClosed        = errors.New("client closed")
+	ErrInvalidFilter       = errors.New("invalid filter")
 )
 
-func statusCode(statusCode int) error {
-	switch statusCode {
-	case 200:
-		return nil
-	case 401:
-		return ErrUnauthorized
-	case 400:
+// maxErrorBodySize caps how much of an error response body is kept on an APIError.
+const maxErrorBodySize = 1 << 20
+
+// APIError is returned when StockX answers with a non-successful status code.
+// It matches the sentinel errors above with errors.Is, so callers can keep
+// checking errors.Is(err, ErrUnauthorized) while still having access to the
+// details StockX sent back.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Code and Message are parsed from the JSON error body when present.
+	Code    string
+	Message string
+	// Body is the raw response body.
+	Body []byte
+	// RetryAfter is the wait requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "stockx: %s %s: %d %s", e.Method, e.URL, e.StatusCode, e.sentinel().Error())
+	if e.Code != "" {
+		fmt.Fprintf(&b, ": %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// Is reports whether target is the sentinel error for e's status code.
+func (e *APIError) Is(target error) bool {
+	return target == e.sentinel()
+}
+
+func (e *APIError) sentinel() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
 		return ErrBadRequest
-	case 500:
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnprocessableEntity:
+		return ErrUnprocessableEntity
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusInternalServerError:
 		return ErrInternal
 	default:
-		return fmt.Errorf(ErrUnknownStatus.Error(), statusCode)
+		return ErrUnknownStatus
+	}
+}
+
+// apiErrorBody covers the error shapes returned by the StockX API and the
+// OAuth token endpoint.
+type apiErrorBody struct {
+	Code             string `json:"code"`
+	ErrorCode        string `json:"errorCode"`
+	Message          string `json:"message"`
+	ErrorMessage     string `json:"errorMessage"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = wait
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.URL = resp.Request.URL.String()
+		}
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.Code = firstNonEmpty(parsed.ErrorCode, parsed.Code, parsed.Error)
+		apiErr.Message = firstNonEmpty(parsed.ErrorMessage, parsed.Message, parsed.ErrorDescription)
+	}
+
+	return apiErr
+}
+
+// checkResponse returns an *APIError when resp does not carry a 2xx status
+// code. The response body is consumed in that case.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return err
+	}
+
+	return newAPIError(resp, body)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
 	}
+	return ""
 }
@@ -0,0 +1,67 @@
+package stockxgo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		retryAfter  string
+		wantCode    string
+		wantMessage string
+		wantWait    time.Duration
+		sentinel    error
+	}{
+		{name: "errorCode", status: 400, body: `{"errorCode":"INVALID_AMOUNT","errorMessage":"amount too low","code":"ignored"}`, wantCode: "INVALID_AMOUNT", wantMessage: "amount too low", sentinel: ErrBadRequest},
+		{name: "code", status: 403, body: `{"code":"FORBIDDEN","message":"not allowed"}`, wantCode: "FORBIDDEN", wantMessage: "not allowed", sentinel: ErrForbidden},
+		{name: "oauth error", status: 401, body: `{"error":"invalid_grant","error_description":"refresh token revoked"}`, wantCode: "invalid_grant", wantMessage: "refresh token revoked", sentinel: ErrUnauthorized},
+		{name: "not found", status: 404, body: `{"code":"NOT_FOUND"}`, wantCode: "NOT_FOUND", sentinel: ErrNotFound},
+		{name: "conflict", status: 409, body: `not json`, sentinel: ErrConflict},
+		{name: "unprocessable", status: 422, body: `{"message":"listing is locked"}`, wantMessage: "listing is locked", sentinel: ErrUnprocessableEntity},
+		{name: "rate limited", status: 429, retryAfter: "7", wantWait: 7 * time.Second, sentinel: ErrTooManyRequests},
+		{name: "rate limited without a usable Retry-After", status: 429, retryAfter: "later", sentinel: ErrTooManyRequests},
+		{name: "internal", status: 500, sentinel: ErrInternal},
+		{name: "unknown", status: 418, sentinel: ErrUnknownStatus},
+	}
+
+	sentinels := []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrUnprocessableEntity, ErrTooManyRequests, ErrInternal, ErrUnknownStatus}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", "https://api.stockx.com/v2/selling/listings/1", nil)
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}, Request: req}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			apiErr := newAPIError(resp, []byte(tt.body))
+
+			if apiErr.Code != tt.wantCode || apiErr.Message != tt.wantMessage {
+				t.Errorf("Code, Message = %q, %q; want %q, %q", apiErr.Code, apiErr.Message, tt.wantCode, tt.wantMessage)
+			}
+			if apiErr.RetryAfter != tt.wantWait {
+				t.Errorf("RetryAfter = %s, want %s", apiErr.RetryAfter, tt.wantWait)
+			}
+			if apiErr.Method != "PATCH" || apiErr.URL != req.URL.String() || string(apiErr.Body) != tt.body {
+				t.Errorf("request details = %s %s %q", apiErr.Method, apiErr.URL, apiErr.Body)
+			}
+
+			for _, sentinel := range sentinels {
+				if got := errors.Is(apiErr, sentinel); got != (sentinel == tt.sentinel) {
+					t.Errorf("errors.Is(err, %q) = %t", sentinel, got)
+				}
+			}
+
+			if msg := apiErr.Error(); !strings.Contains(msg, tt.sentinel.Error()) || !strings.Contains(msg, tt.wantCode) {
+				t.Errorf("Error() = %q", msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package stockxtest
+
+import (
+	"net/http"
+	"strings"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+// AddProduct adds a product and its variants to the catalog.
+func (s *Server) AddProduct(product stockxgo.Product, variants ...stockxgo.ProductVariant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.products = append(s.products, product)
+	for _, v := range variants {
+		v.ProductID = product.ProductID
+		s.variants[product.ProductID] = append(s.variants[product.ProductID], v)
+	}
+}
+
+// SetMarketData sets the market data served for a variant. ProductID,
+// VariantID and CurrencyCode of data select the variant and currency.
+func (s *Server) SetMarketData(data stockxgo.MarketData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.marketData[marketDataKey{data.ProductID, data.VariantID, data.CurrencyCode}] = data
+}
+
+func (s *Server) registerCatalog(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v2/catalog/search", s.handleSearch)
+	mux.HandleFunc("GET /v2/catalog/products/{productId}", s.handleGetProduct)
+	mux.HandleFunc("GET /v2/catalog/products/{productId}/variants", s.handleGetVariants)
+	mux.HandleFunc("GET /v2/catalog/products/{productId}/variants/{variantId}", s.handleGetVariant)
+	mux.HandleFunc("GET /v2/catalog/products/{productId}/market-data", s.handleProductMarketData)
+	mux.HandleFunc("GET /v2/catalog/products/{productId}/variants/{variantId}/market-data", s.handleVariantMarketData)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	s.mu.Lock()
+	var matched []stockxgo.Product
+	for _, p := range s.products {
+		if query == "" || strings.Contains(strings.ToLower(p.Title), query) ||
+			strings.Contains(strings.ToLower(p.StyleID), query) || strings.Contains(strings.ToLower(p.Brand), query) {
+			matched = append(matched, p)
+		}
+	}
+	s.mu.Unlock()
+
+	page, pageNumber, pageSize, hasNextPage := paginate(matched, r.URL.Query(), 10)
+	writeJSON(w, http.StatusOK, stockxgo.SearchCatalogResponse{
+		Count:       len(matched),
+		PageSize:    pageSize,
+		PageNumber:  pageNumber,
+		HasNextPage: hasNextPage,
+		Products:    append([]stockxgo.Product{}, page...),
+	})
+}
+
+func (s *Server) handleGetProduct(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("productId")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.products {
+		if p.ProductID == productID {
+			writeJSON(w, http.StatusOK, p)
+			return
+		}
+	}
+
+	notFound(w, "product", productID)
+}
+
+func (s *Server) handleGetVariants(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("productId")
+
+	s.mu.Lock()
+	variants, ok := s.variants[productID]
+	s.mu.Unlock()
+
+	if !ok {
+		notFound(w, "product", productID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, variants)
+}
+
+func (s *Server) handleGetVariant(w http.ResponseWriter, r *http.Request) {
+	variant, ok := s.findVariant(r.PathValue("productId"), r.PathValue("variantId"))
+	if !ok {
+		notFound(w, "variant", r.PathValue("variantId"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, variant)
+}
+
+func (s *Server) handleProductMarketData(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("productId")
+	currencyCode := r.URL.Query().Get("currencyCode")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []stockxgo.MarketData{}
+	for _, v := range s.variants[productID] {
+		if md, ok := s.marketData[marketDataKey{productID, v.VariantID, currencyCode}]; ok {
+			data = append(data, md)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (s *Server) handleVariantMarketData(w http.ResponseWriter, r *http.Request) {
+	key := marketDataKey{r.PathValue("productId"), r.PathValue("variantId"), r.URL.Query().Get("currencyCode")}
+
+	s.mu.Lock()
+	data, ok := s.marketData[key]
+	s.mu.Unlock()
+
+	if !ok {
+		notFound(w, "market data for variant", key.variantID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (s *Server) findVariant(productID, variantID string) (stockxgo.ProductVariant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range s.variants[productID] {
+		if v.VariantID == variantID {
+			return v, true
+		}
+	}
+
+	return stockxgo.ProductVariant{}, false
+}
+
+// findProductForVariantLocked returns the product a variant belongs to.
+func (s *Server) findProductForVariantLocked(variantID string) (stockxgo.Product, stockxgo.ProductVariant, bool) {
+	for _, p := range s.products {
+		for _, v := range s.variants[p.ProductID] {
+			if v.VariantID == variantID {
+				return p, v, true
+			}
+		}
+	}
+
+	return stockxgo.Product{}, stockxgo.ProductVariant{}, false
+}
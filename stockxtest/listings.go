@@ -0,0 +1,357 @@
+package stockxtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+type listingPayload struct {
+	Amount       stockxgo.Money `json:"amount"`
+	VariantID    string         `json:"variantId"`
+	CurrencyCode string         `json:"currencyCode"`
+	ExpiresAt    string         `json:"expiresAt"`
+	Active       *bool          `json:"active"`
+}
+
+// AddListing stores a listing as if it had been created through the API. A
+// missing ListingID is generated. It returns the stored listing.
+func (s *Server) AddListing(listing stockxgo.Listing) stockxgo.Listing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if listing.ListingID == "" {
+		listing.ListingID = "listing-" + s.newIDLocked()
+	}
+
+	if _, exists := s.listings[listing.ListingID]; !exists {
+		s.listingOrder = append(s.listingOrder, listing.ListingID)
+	}
+
+	stored := listing
+	s.listings[listing.ListingID] = &stored
+	return stored
+}
+
+// Listing returns the current state of a stored listing.
+func (s *Server) Listing(listingID string) (stockxgo.Listing, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listing, ok := s.listings[listingID]
+	if !ok {
+		return stockxgo.Listing{}, false
+	}
+	return *listing, true
+}
+
+// Operations returns the operations recorded for a listing.
+func (s *Server) Operations(listingID string) []stockxgo.GetListingOperationResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var operations []stockxgo.GetListingOperationResponse
+	for _, op := range s.operations[listingID] {
+		operations = append(operations, *op)
+	}
+	return operations
+}
+
+// FailOperation marks an operation as FAILED with the given error detail.
+func (s *Server) FailOperation(listingID, operationID string, detail any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, op := range s.operations[listingID] {
+		if op.OperationID == operationID {
+			op.OperationStatus = stockxgo.OperationStatusFailed
+			op.Error = detail
+			op.UpdatedAt = time.Now().UTC()
+		}
+	}
+}
+
+func (s *Server) registerListings(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v2/selling/listings", s.handleGetAllListings)
+	mux.HandleFunc("POST /v2/selling/listings", s.handleCreateListing)
+	mux.HandleFunc("GET /v2/selling/listings/{listingId}", s.handleGetListing)
+	mux.HandleFunc("PATCH /v2/selling/listings/{listingId}", s.handleUpdateListing)
+	mux.HandleFunc("DELETE /v2/selling/listings/{listingId}", s.handleDeleteListing)
+	mux.HandleFunc("PUT /v2/selling/listings/{listingId}/activate", s.handleActivateListing)
+	mux.HandleFunc("PUT /v2/selling/listings/{listingId}/deactivate", s.handleDeactivateListing)
+	mux.HandleFunc("GET /v2/selling/listings/{listingId}/operations", s.handleGetAllListingOperations)
+	mux.HandleFunc("GET /v2/selling/listings/{listingId}/operations/{operationId}", s.handleGetListingOperation)
+}
+
+func (s *Server) handleGetAllListings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	statuses := splitList(query.Get("listingStatuses"))
+	productIDs := splitList(query.Get("productIds"))
+	variantIDs := splitList(query.Get("variantIds"))
+
+	s.mu.Lock()
+	var matched []stockxgo.Listing
+	for _, id := range s.listingOrder {
+		l := s.listings[id]
+		if statuses != nil && !statuses[string(l.Status)] {
+			continue
+		}
+		if productIDs != nil && !productIDs[l.Product.ProductID] {
+			continue
+		}
+		if variantIDs != nil && !variantIDs[l.Variant.VariantID] {
+			continue
+		}
+		matched = append(matched, *l)
+	}
+	s.mu.Unlock()
+
+	page, pageNumber, pageSize, hasNextPage := paginate(matched, query, 100)
+	writeJSON(w, http.StatusOK, stockxgo.GetAllListingsResponse{
+		Count:       len(matched),
+		PageSize:    pageSize,
+		PageNumber:  pageNumber,
+		HasNextPage: hasNextPage,
+		Listings:    append([]stockxgo.Listing{}, page...),
+	})
+}
+
+func (s *Server) handleCreateListing(w http.ResponseWriter, r *http.Request) {
+	var payload listingPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_BODY", err.Error())
+		return
+	}
+
+	if payload.VariantID == "" || !payload.Amount.IsSet() {
+		writeError(w, http.StatusBadRequest, "INVALID_LISTING", "variantId and amount are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	product, variant, ok := s.findProductForVariantLocked(payload.VariantID)
+	if !ok {
+		notFound(w, "variant", payload.VariantID)
+		return
+	}
+
+	now := time.Now().UTC()
+	listing := &stockxgo.Listing{
+		ListingID:     "listing-" + s.newIDLocked(),
+		Status:        stockxgo.ListingStatusInactive,
+		Amount:        payload.Amount.WithCurrency(payload.CurrencyCode),
+		CurrencyCode:  payload.CurrencyCode,
+		InventoryType: string(stockxgo.InventoryTypeStandard),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	listing.Product.ProductID = product.ProductID
+	listing.Product.ProductName = product.Title
+	listing.Product.StyleID = product.StyleID
+	listing.Variant.VariantID = variant.VariantID
+	listing.Variant.VariantName = variant.VariantName
+	listing.Variant.VariantValue = variant.VariantValue
+
+	if payload.Active != nil && *payload.Active {
+		activateLocked(listing, parseExpiry(payload.ExpiresAt), s.newIDLocked(), now)
+	}
+
+	s.listings[listing.ListingID] = listing
+	s.listingOrder = append(s.listingOrder, listing.ListingID)
+
+	s.writeModificationLocked(w, listing.ListingID, stockxgo.OperationTypeCreate)
+}
+
+func (s *Server) handleGetListing(w http.ResponseWriter, r *http.Request) {
+	listingID := r.PathValue("listingId")
+
+	s.mu.Lock()
+	listing, ok := s.listings[listingID]
+	var copied stockxgo.Listing
+	if ok {
+		copied = *listing
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		notFound(w, "listing", listingID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, copied.ToGetListingResponse())
+}
+
+func (s *Server) handleUpdateListing(w http.ResponseWriter, r *http.Request) {
+	s.modifyListing(w, r, stockxgo.OperationTypeUpdate, func(listing *stockxgo.Listing, payload listingPayload, now time.Time) {
+		if payload.Amount.IsSet() {
+			listing.Amount = payload.Amount
+		}
+		if payload.CurrencyCode != "" {
+			listing.CurrencyCode = payload.CurrencyCode
+		}
+		listing.Amount = listing.Amount.WithCurrency(listing.CurrencyCode)
+		if expiresAt := parseExpiry(payload.ExpiresAt); !expiresAt.IsZero() {
+			listing.Ask.AskExpiresAt = expiresAt
+		}
+		listing.Ask.AskUpdatedAt = now
+	})
+}
+
+func (s *Server) handleActivateListing(w http.ResponseWriter, r *http.Request) {
+	s.modifyListing(w, r, stockxgo.OperationTypeActivate, func(listing *stockxgo.Listing, payload listingPayload, now time.Time) {
+		if payload.Amount.IsSet() {
+			listing.Amount = payload.Amount
+		}
+		if payload.CurrencyCode != "" {
+			listing.CurrencyCode = payload.CurrencyCode
+		}
+		listing.Amount = listing.Amount.WithCurrency(listing.CurrencyCode)
+		activateLocked(listing, parseExpiry(payload.ExpiresAt), listing.ListingID+"-ask", now)
+	})
+}
+
+func (s *Server) handleDeactivateListing(w http.ResponseWriter, r *http.Request) {
+	s.modifyListing(w, r, stockxgo.OperationTypeDeactivate, func(listing *stockxgo.Listing, payload listingPayload, now time.Time) {
+		listing.Status = stockxgo.ListingStatusInactive
+	})
+}
+
+func (s *Server) handleDeleteListing(w http.ResponseWriter, r *http.Request) {
+	s.modifyListing(w, r, stockxgo.OperationTypeDelete, func(listing *stockxgo.Listing, payload listingPayload, now time.Time) {
+		listing.Status = stockxgo.ListingStatusDeleted
+	})
+}
+
+func (s *Server) modifyListing(w http.ResponseWriter, r *http.Request, operationType stockxgo.OperationType, apply func(*stockxgo.Listing, listingPayload, time.Time)) {
+	listingID := r.PathValue("listingId")
+
+	var payload listingPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "INVALID_BODY", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listing, ok := s.listings[listingID]
+	if !ok || listing.Status == stockxgo.ListingStatusDeleted {
+		notFound(w, "listing", listingID)
+		return
+	}
+
+	now := time.Now().UTC()
+	apply(listing, payload, now)
+	listing.UpdatedAt = now
+
+	s.writeModificationLocked(w, listingID, operationType)
+}
+
+func (s *Server) writeModificationLocked(w http.ResponseWriter, listingID string, operationType stockxgo.OperationType) {
+	now := time.Now().UTC()
+
+	status := stockxgo.OperationStatusSucceeded
+	if s.AsyncOperations {
+		status = stockxgo.OperationStatusPending
+	}
+
+	op := &stockxgo.GetListingOperationResponse{
+		ListingID:             listingID,
+		OperationID:           "operation-" + s.newIDLocked(),
+		OperationType:         operationType,
+		OperationStatus:       status,
+		OperationInitiatedBy:  "USER",
+		OperationInitiatedVia: "PUBLIC-API",
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	s.operations[listingID] = append(s.operations[listingID], op)
+
+	writeJSON(w, http.StatusOK, stockxgo.ListingModificationResponse{
+		ListingID:             op.ListingID,
+		OperationID:           op.OperationID,
+		OperationType:         op.OperationType,
+		OperationStatus:       op.OperationStatus,
+		OperationURL:          fmt.Sprintf("%s/v2/selling/listings/%s/operations/%s", s.URL(), listingID, op.OperationID),
+		OperationInitiatedBy:  op.OperationInitiatedBy,
+		OperationInitiatedVia: op.OperationInitiatedVia,
+		CreatedAt:             op.CreatedAt,
+		UpdatedAt:             op.UpdatedAt,
+	})
+}
+
+func (s *Server) handleGetAllListingOperations(w http.ResponseWriter, r *http.Request) {
+	listingID := r.PathValue("listingId")
+
+	s.mu.Lock()
+	_, ok := s.listings[listingID]
+	operations := []stockxgo.GetListingOperationResponse{}
+	for _, op := range s.operations[listingID] {
+		operations = append(operations, *op)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		notFound(w, "listing", listingID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"nextCursor": "",
+		"operations": operations,
+	})
+}
+
+func (s *Server) handleGetListingOperation(w http.ResponseWriter, r *http.Request) {
+	listingID, operationID := r.PathValue("listingId"), r.PathValue("operationId")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, op := range s.operations[listingID] {
+		if op.OperationID != operationID {
+			continue
+		}
+
+		current := *op
+
+		// Pending operations complete once they have been observed.
+		if op.OperationStatus == stockxgo.OperationStatusPending {
+			op.OperationStatus = stockxgo.OperationStatusSucceeded
+			op.UpdatedAt = time.Now().UTC()
+		}
+
+		writeJSON(w, http.StatusOK, current)
+		return
+	}
+
+	notFound(w, "operation", operationID)
+}
+
+func activateLocked(listing *stockxgo.Listing, expiresAt time.Time, askID string, now time.Time) {
+	listing.Status = stockxgo.ListingStatusActive
+	if listing.Ask.AskID == "" {
+		listing.Ask.AskID = askID
+		listing.Ask.AskCreatedAt = now
+	}
+	listing.Ask.AskUpdatedAt = now
+	if !expiresAt.IsZero() {
+		listing.Ask.AskExpiresAt = expiresAt
+	}
+}
+
+// parseExpiry parses an expiresAt value, treating Go's zero time as unset.
+func parseExpiry(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil || t.Year() <= 1 {
+		return time.Time{}
+	}
+	return t
+}
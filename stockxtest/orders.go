@@ -0,0 +1,142 @@
+package stockxtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+// historicalStatuses are the order statuses served by the history endpoint;
+// every other order is active.
+var historicalStatuses = map[string]bool{
+	string(stockxgo.OrderStatusPayoutCompleted): true,
+	string(stockxgo.OrderStatusSystemFulfilled): true,
+	string(stockxgo.OrderStatusPayoutFailed):    true,
+	string(stockxgo.OrderStatusSuspended):       true,
+	string(stockxgo.OrderStatusCCAuthFailed):    true,
+}
+
+// AddOrder stores an order. Orders in a completed or failed state are served
+// by the history endpoint, the others by the active orders endpoint.
+func (s *Server) AddOrder(order stockxgo.GetSingleOrderResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders = append(s.orders, order)
+}
+
+// SetShippingLabel serves data as an order's shipping label and points its
+// ShippingLabelURL at it. Call it after AddOrder.
+func (s *Server) SetShippingLabel(orderNumber, contentType string, data []byte) {
+	s.setShippingDocument(orderNumber, "label", contentType, data, func(shipment *stockxgo.Shipment, url string) {
+		shipment.ShippingLabelURL = url
+	})
+}
+
+// SetShippingDocument serves data as an order's shipping document and points
+// its ShippingDocumentURL at it. Call it after AddOrder.
+func (s *Server) SetShippingDocument(orderNumber, contentType string, data []byte) {
+	s.setShippingDocument(orderNumber, "document", contentType, data, func(shipment *stockxgo.Shipment, url string) {
+		shipment.ShippingDocumentURL = url
+	})
+}
+
+type shippingDocument struct {
+	contentType string
+	data        []byte
+}
+
+func (s *Server) setShippingDocument(orderNumber, shippingID, contentType string, data []byte, link func(*stockxgo.Shipment, string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.documents[orderNumber+"/"+shippingID] = shippingDocument{contentType: contentType, data: data}
+
+	for i := range s.orders {
+		if s.orders[i].OrderNumber == orderNumber {
+			link(&s.orders[i].Shipment, fmt.Sprintf("%s/v2/selling/orders/%s/shipping-document/%s", s.URL(), orderNumber, shippingID))
+		}
+	}
+}
+
+func (s *Server) registerOrders(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v2/selling/orders/active", s.handleOrders(false))
+	mux.HandleFunc("GET /v2/selling/orders/history", s.handleOrders(true))
+	mux.HandleFunc("GET /v2/selling/orders/{orderNumber}", s.handleGetOrder)
+	mux.HandleFunc("GET /v2/selling/orders/{orderNumber}/shipping-document/{shippingId}", s.handleShippingDocument)
+}
+
+func (s *Server) handleOrders(historical bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		inventoryTypes := splitList(query.Get("inventoryTypes"))
+
+		s.mu.Lock()
+		var matched []stockxgo.Order
+		for _, o := range s.orders {
+			if historicalStatuses[string(o.Status)] != historical {
+				continue
+			}
+			if v := query.Get("orderStatus"); v != "" && string(o.Status) != v {
+				continue
+			}
+			if v := query.Get("productId"); v != "" && o.Product.ProductID != v {
+				continue
+			}
+			if v := query.Get("variantId"); v != "" && o.Variant.VariantID != v {
+				continue
+			}
+			if inventoryTypes != nil && !inventoryTypes[string(o.InventoryType)] {
+				continue
+			}
+
+			matched = append(matched, o.ToOrder())
+		}
+		s.mu.Unlock()
+
+		page, pageNumber, pageSize, hasNextPage := paginate(matched, query, 20)
+		writeJSON(w, http.StatusOK, stockxgo.OrdersResponse{
+			Count:       len(matched),
+			PageSize:    pageSize,
+			PageNumber:  pageNumber,
+			HasNextPage: hasNextPage,
+			Orders:      append([]stockxgo.Order{}, page...),
+		})
+	}
+}
+
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	orderNumber := r.PathValue("orderNumber")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.orders {
+		if o.OrderNumber == orderNumber {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+	}
+
+	notFound(w, "order", orderNumber)
+}
+
+func (s *Server) handleShippingDocument(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("orderNumber") + "/" + r.PathValue("shippingId")
+
+	s.mu.Lock()
+	document, ok := s.documents[key]
+	s.mu.Unlock()
+
+	if !ok {
+		notFound(w, "shipping document", key)
+		return
+	}
+
+	w.Header().Set("Content-Type", document.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document.data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(document.data)
+}
@@ -0,0 +1,562 @@
+package stockxtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces secrets in recorded cassettes.
+const Redacted = "REDACTED"
+
+var ErrUnmatchedRequest = errors.New("stockxtest: no recorded interaction matches request")
+
+// RecorderMode selects whether a Recorder talks to the network.
+type RecorderMode int
+
+const (
+	// ModeReplay answers requests from the cassette. Requests without a
+	// recorded interaction are sent to the network and appended to the
+	// cassette, unless the recorder is strict.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends every request to the network and replaces the
+	// cassette with the new interactions.
+	ModeRecord
+)
+
+// Cassette is the on-disk format of recorded traffic.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it got.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// BodyEncoding is "base64" when the body is not valid UTF-8.
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records StockX traffic to a cassette
+// file and replays it without network access. Requests are matched by
+// method, path and query with parameters in sorted order. Identical requests
+// are replayed in the order they were recorded; once all of them have been
+// used the last one is repeated.
+//
+// Authorization and x-api-key headers, client secrets and tokens are
+// redacted before anything is written to disk:
+//
+//	rec, err := stockxtest.NewRecorder("testdata/listings.json", stockxtest.WithStrict(true))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := stockxgo.New(stockxgo.WithTransport(rec), ...)
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	strict    bool
+	transport http.RoundTripper
+	headers   map[string]bool
+	fields    map[string]bool
+	// formFields are only redacted in form bodies and query strings. JSON
+	// bodies use the same names for data tests need, such as the code of a
+	// StockX error.
+	formFields map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	dirty    bool
+}
+
+type RecorderOption func(*Recorder)
+
+// WithMode sets the recorder mode. The default is ModeReplay.
+func WithMode(mode RecorderMode) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithStrict makes the recorder fail requests that have no recorded
+// interaction with ErrUnmatchedRequest instead of sending them to the
+// network. Use it in CI.
+func WithStrict(strict bool) RecorderOption {
+	return func(r *Recorder) {
+		r.strict = strict
+	}
+}
+
+// WithRecorderTransport sets the transport used to reach the network. The
+// default is http.DefaultTransport.
+func WithRecorderTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithRedactedHeaders redacts additional request and response headers.
+func WithRedactedHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.headers[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// WithRedactedFields redacts additional form, query and JSON fields.
+func WithRedactedFields(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.fields[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// NewRecorder creates a recorder backed by the cassette at path. In
+// ModeReplay an existing cassette is loaded; a missing one is only an error
+// in strict mode.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		transport: http.DefaultTransport,
+		headers: map[string]bool{
+			"Authorization": true,
+			"X-Api-Key":     true,
+			"Cookie":        true,
+			"Set-Cookie":    true,
+		},
+		fields: map[string]bool{
+			"client_secret": true,
+			"code_verifier": true,
+			"access_token":  true,
+			"refresh_token": true,
+			"id_token":      true,
+			"accesstoken":   true,
+			"refreshtoken":  true,
+		},
+		formFields: map[string]bool{
+			// The OAuth authorization code.
+			"code": true,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !r.strict {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("stockxtest: reading cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := r.recordRequest(req, body)
+
+	if r.mode == ModeReplay {
+		if interaction, ok := r.match(recorded); ok {
+			return replay(req, interaction.Response)
+		}
+		if r.strict {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, recorded.URL)
+		}
+	}
+
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+		outgoing.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: r.recordResponse(resp, respBody),
+	})
+	r.used = append(r.used, true)
+	r.dirty = true
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Stop writes newly recorded interactions to the cassette file. It does
+// nothing when every request was replayed.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(r.path, append(data, '\n')); err != nil {
+		return err
+	}
+
+	r.dirty = false
+	return nil
+}
+
+// Unused returns the recorded interactions that were never replayed, which
+// usually means the code under test stopped making a request.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+
+	return unused
+}
+
+func (r *Recorder) match(req RecordedRequest) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request.Method != req.Method || interaction.Request.URL != req.URL {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+
+	if last < 0 {
+		return Interaction{}, false
+	}
+
+	return r.cassette.Interactions[last], true
+}
+
+// recordRequest returns the redacted form of a request. Its URL holds only
+// the path and the normalised query, so cassettes recorded against one host
+// replay against any base URL.
+func (r *Recorder) recordRequest(req *http.Request, body []byte) RecordedRequest {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.EscapedPath(),
+		Header: r.redactHeader(req.Header),
+	}
+
+	if query := r.redactValues(req.URL.Query()); len(query) > 0 {
+		recorded.URL += "?" + normaliseQuery(query)
+	}
+
+	recorded.Body, recorded.BodyEncoding = encodeBody(r.redactBody(req.Header.Get("Content-Type"), body))
+	return recorded
+}
+
+func (r *Recorder) recordResponse(resp *http.Response, body []byte) RecordedResponse {
+	recorded := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     r.redactHeader(resp.Header),
+	}
+	recorded.Header.Del("Content-Length")
+
+	recorded.Body, recorded.BodyEncoding = encodeBody(r.redactBody(resp.Header.Get("Content-Type"), body))
+	return recorded
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for name := range redacted {
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			redacted[name] = []string{Redacted}
+		}
+	}
+
+	return redacted
+}
+
+func (r *Recorder) redactValues(values url.Values) url.Values {
+	for name := range values {
+		if r.fields[strings.ToLower(name)] || r.formFields[strings.ToLower(name)] {
+			values[name] = []string{Redacted}
+		}
+	}
+
+	return values
+}
+
+func (r *Recorder) redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		return []byte(normaliseQuery(r.redactValues(values)))
+	}
+
+	// The body is rewritten token by token so numbers keep their exact
+	// digits and keys their order; only the redacted values change.
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	changed, err := r.redactJSON(dec, &out, redactFields)
+	if err != nil || !changed {
+		return body
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return body
+	}
+
+	return out.Bytes()
+}
+
+// jsonRedaction says how redactJSON treats the value it copies.
+type jsonRedaction int
+
+const (
+	// redactFields redacts the string values of matching fields.
+	redactFields jsonRedaction = iota
+	// redactString redacts the value itself if it is a string.
+	redactString
+	// redactNothing copies the value unchanged.
+	redactNothing
+)
+
+// redactJSON copies the next JSON value from dec to out, redacting according
+// to mode, and reports whether anything was redacted.
+func (r *Recorder) redactJSON(dec *json.Decoder, out *bytes.Buffer, mode jsonRedaction) (bool, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	// The children of a matching field that is not a string are kept as
+	// they are.
+	childMode := redactFields
+	if mode != redactFields {
+		childMode = redactNothing
+	}
+
+	switch v := token.(type) {
+	case json.Delim:
+		out.WriteRune(rune(v))
+		closing := '}'
+		if v == '[' {
+			closing = ']'
+		}
+
+		changed := false
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+
+			valueMode := childMode
+			if v == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return false, err
+				}
+				name, _ := key.(string)
+				writeJSONString(out, name)
+				out.WriteByte(':')
+
+				if childMode == redactFields && r.fields[strings.ToLower(name)] {
+					valueMode = redactString
+				}
+			}
+
+			c, err := r.redactJSON(dec, out, valueMode)
+			if err != nil {
+				return false, err
+			}
+			changed = c || changed
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return false, err
+		}
+		out.WriteRune(closing)
+		return changed, nil
+	case string:
+		if mode == redactString {
+			writeJSONString(out, Redacted)
+			return true, nil
+		}
+		writeJSONString(out, v)
+	case json.Number:
+		out.WriteString(v.String())
+	case bool:
+		out.WriteString(strconv.FormatBool(v))
+	case nil:
+		out.WriteString("null")
+	}
+
+	return false, nil
+}
+
+func writeJSONString(out *bytes.Buffer, s string) {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	// Encode ends every value with a newline.
+	out.Truncate(out.Len() - 1)
+}
+
+func replay(req *http.Request, recorded RecordedResponse) (*http.Response, error) {
+	body, err := decodeBody(recorded.Body, recorded.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// normaliseQuery encodes a query with its keys and the values of each key
+// sorted, so that parameter order does not affect matching.
+func normaliseQuery(values url.Values) string {
+	sorted := make(url.Values, len(values))
+	for key, vals := range values {
+		sorted[key] = slices.Sorted(slices.Values(vals))
+	}
+
+	return sorted.Encode()
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("stockxtest: unknown body encoding %q", encoding)
+	}
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, path)
+}
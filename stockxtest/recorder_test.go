@@ -0,0 +1,157 @@
+package stockxtest_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+const clientSecret = "super-secret-value"
+
+// exercise makes the requests recorded and replayed by TestRecorder.
+func exercise(t *testing.T, client stockxgo.StockXClient) {
+	t.Helper()
+
+	if err := client.ExchangeCode("authorization-code-value", ""); err != nil {
+		t.Fatalf("ExchangeCode: %v", err)
+	}
+
+	product, err := client.GetSingleProduct("product-1")
+	if err != nil || product.ProductID != "product-1" {
+		t.Fatalf("GetSingleProduct = %+v, %v", product, err)
+	}
+
+	_, err = client.GetSingleProduct("product-2")
+	var apiErr *stockxgo.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetSingleProduct error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "INVALID_AMOUNT" || apiErr.Message != "amount too low" {
+		t.Errorf("APIError = %d %q %q, want 400 INVALID_AMOUNT \"amount too low\"", apiErr.StatusCode, apiErr.Code, apiErr.Message)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.APIKey = "api-key-value"
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+	srv.Inject(stockxtest.Failure{
+		Path:   "/v2/catalog/products/product-2",
+		Status: http.StatusBadRequest,
+		Body:   `{"code":"INVALID_AMOUNT","message":"amount too low"}`,
+	})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	newClient := func(rec *stockxtest.Recorder) stockxgo.StockXClient {
+		return srv.Client(
+			stockxgo.WithTransport(rec),
+			stockxgo.WithCredentials("client-id", clientSecret),
+			stockxgo.WithRetryPolicy(stockxgo.NoRetryPolicy()),
+		)
+	}
+
+	rec, err := stockxtest.NewRecorder(path, stockxtest.WithMode(stockxtest.ModeRecord))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newClient(rec)
+	exercise(t, client)
+	session := client.GetSession()
+	client.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette := string(data)
+
+	for _, secret := range []string{clientSecret, "authorization-code-value", session.AccessToken, session.RefreshToken, srv.APIKey} {
+		if secret != "" && strings.Contains(cassette, secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+	if !strings.Contains(cassette, "INVALID_AMOUNT") {
+		t.Error("cassette lost the code of the StockX error body")
+	}
+
+	// Replay with the server gone: every answer must come from the cassette.
+	srv.ResetRequests()
+
+	rec, err = stockxtest.NewRecorder(path, stockxtest.WithStrict(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = newClient(rec)
+	defer client.Close()
+
+	exercise(t, client)
+
+	if unused := rec.Unused(); len(unused) > 0 {
+		t.Errorf("%d interactions were not replayed", len(unused))
+	}
+	if requests := srv.Requests(); len(requests) > 0 {
+		t.Errorf("replay sent %d requests to the network", len(requests))
+	}
+
+	if _, err := client.GetSingleProduct("product-3"); !errors.Is(err, stockxtest.ErrUnmatchedRequest) {
+		t.Errorf("unrecorded request error = %v, want ErrUnmatchedRequest", err)
+	}
+}
+
+func TestRecorderKeepsJSONBody(t *testing.T) {
+	const body = `{"z":1,"accessToken":"secret-token","amount":12345678901234567890.10,"nested":{"refresh_token":"secret-refresh","ratio":1e3,"tags":["<a>",null,true]},"code":"KEEP"}`
+	const want = `{"z":1,"accessToken":"REDACTED","amount":12345678901234567890.10,"nested":{"refresh_token":"REDACTED","ratio":1e3,"tags":["<a>",null,true]},"code":"KEEP"}`
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}))
+	defer upstream.Close()
+
+	fetch := func(rec *stockxtest.Recorder) string {
+		t.Helper()
+
+		resp, err := (&http.Client{Transport: rec}).Get(upstream.URL + "/session")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := stockxtest.NewRecorder(path, stockxtest.WithMode(stockxtest.ModeRecord))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetch(rec)
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = stockxtest.NewRecorder(path, stockxtest.WithStrict(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fetch(rec); got != want {
+		t.Errorf("replayed body =\n%s\nwant\n%s", got, want)
+	}
+}
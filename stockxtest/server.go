@@ -0,0 +1,458 @@
+// Package stockxtest provides an in-memory fake of the StockX API and a
+// record/replay transport for tests.
+//
+// The fake serves the catalog, listings, orders and OAuth endpoints over
+// httptest, keeps listings and their operations in memory, can be scripted to
+// fail or slow down, and records every request it receives:
+//
+//	srv := stockxtest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddProduct(product, variants...)
+//	client := srv.Client()
+//	defer client.Close()
+//
+//	listings, err := client.GetAllListings()
+//	srv.AssertRequested(t, "GET", "/v2/selling/listings")
+package stockxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+)
+
+// Server is a fake StockX API. It is safe for concurrent use.
+type Server struct {
+	// APIKey, when set, is required in the x-api-key header of API requests.
+	APIKey string
+	// AsyncOperations makes listing operations start out PENDING. A pending
+	// operation is reported as PENDING once and has succeeded on the next
+	// fetch, unless it was failed with FailOperation first.
+	AsyncOperations bool
+
+	server *httptest.Server
+
+	mu           sync.Mutex
+	nextID       int
+	tokens       map[string]bool
+	refresh      map[string]bool
+	products     []stockxgo.Product
+	variants     map[string][]stockxgo.ProductVariant
+	marketData   map[marketDataKey]stockxgo.MarketData
+	listings     map[string]*stockxgo.Listing
+	listingOrder []string
+	operations   map[string][]*stockxgo.GetListingOperationResponse
+	orders       []stockxgo.GetSingleOrderResponse
+	documents    map[string]shippingDocument
+	failures     []*Failure
+	requests     []Request
+}
+
+type marketDataKey struct {
+	productID    string
+	variantID    string
+	currencyCode string
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Failure scripts the server to misbehave for matching requests.
+type Failure struct {
+	// Method and Path restrict the requests the failure applies to. An
+	// empty Method matches any method; Path matches as a prefix and an empty
+	// Path matches every request.
+	Method string
+	Path   string
+	// Status is the status code to answer with. Zero serves the request
+	// normally, which combined with Delay simulates a slow response.
+	Status int
+	// Body is sent as the response body. Defaults to a JSON error.
+	Body string
+	// Header is added to the response, for example a Retry-After header.
+	Header http.Header
+	// Delay is waited before answering.
+	Delay time.Duration
+	// Times is how many requests the failure applies to. Zero means every
+	// matching request until ClearFailures is called.
+	Times int
+}
+
+// NewServer starts a fake StockX API server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		tokens:     make(map[string]bool),
+		refresh:    make(map[string]bool),
+		variants:   make(map[string][]stockxgo.ProductVariant),
+		marketData: make(map[marketDataKey]stockxgo.MarketData),
+		listings:   make(map[string]*stockxgo.Listing),
+		operations: make(map[string][]*stockxgo.GetListingOperationResponse),
+		documents:  make(map[string]shippingDocument),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", s.handleToken)
+	s.registerCatalog(mux)
+	s.registerListings(mux)
+	s.registerOrders(mux)
+
+	s.server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// URL returns the base URL of the server, usable for both
+// stockxgo.WithBaseURL and stockxgo.WithAuthBaseURL.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client pointed at the server and holding a valid session.
+// opts are applied after the defaults and can override them.
+func (s *Server) Client(opts ...stockxgo.ClientOption) stockxgo.StockXClient {
+	access, refresh := s.IssueTokens()
+
+	defaults := []stockxgo.ClientOption{
+		stockxgo.WithBaseURL(s.URL()),
+		stockxgo.WithAuthBaseURL(s.URL()),
+		stockxgo.WithAPIKey(s.APIKey),
+		stockxgo.WithSession(stockxgo.Session{
+			AccessToken:  access,
+			RefreshToken: refresh,
+			ExpiresIn:    3600,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}),
+	}
+
+	return stockxgo.New(append(defaults, opts...)...)
+}
+
+// IssueTokens creates a valid access and refresh token pair.
+func (s *Server) IssueTokens() (accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accessToken = "access-" + s.newIDLocked()
+	refreshToken = "refresh-" + s.newIDLocked()
+	s.tokens[accessToken] = true
+	s.refresh[refreshToken] = true
+	return accessToken, refreshToken
+}
+
+// ExpireTokens invalidates every access token issued so far, so the next API
+// request is answered with 401. Refresh tokens stay valid.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = make(map[string]bool)
+}
+
+// Inject adds a scripted failure. Failures are matched in the order they
+// were added.
+func (s *Server) Inject(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := failure
+	s.failures = append(s.failures, &f)
+}
+
+// FailNext answers the next request, whatever it is, with status.
+func (s *Server) FailNext(status int) {
+	s.Inject(Failure{Status: status, Times: 1})
+}
+
+// ClearFailures removes every scripted failure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// Requests returns every request received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for method and path. An empty
+// method matches any method.
+func (s *Server) RequestsTo(method, path string) []Request {
+	var matched []Request
+	for _, r := range s.Requests() {
+		if (method == "" || r.Method == method) && r.Path == path {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// AssertRequested fails t unless a request for method and path was received.
+// It returns the last matching request.
+func (s *Server) AssertRequested(t testing.TB, method, path string) Request {
+	t.Helper()
+
+	matched := s.RequestsTo(method, path)
+	if len(matched) == 0 {
+		t.Errorf("stockxtest: expected a %s %s request, got none", method, path)
+		return Request{}
+	}
+
+	return matched[len(matched)-1]
+}
+
+// AssertNotRequested fails t if a request for method and path was received.
+func (s *Server) AssertNotRequested(t testing.TB, method, path string) {
+	t.Helper()
+
+	if matched := s.RequestsTo(method, path); len(matched) > 0 {
+		t.Errorf("stockxtest: expected no %s %s request, got %d", method, path, len(matched))
+	}
+}
+
+// AssertRequestCount fails t unless exactly n requests for method and path
+// were received.
+func (s *Server) AssertRequestCount(t testing.TB, method, path string, n int) {
+	t.Helper()
+
+	if matched := s.RequestsTo(method, path); len(matched) != n {
+		t.Errorf("stockxtest: expected %d %s %s requests, got %d", n, method, path, len(matched))
+	}
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		failure := s.matchFailureLocked(r)
+		s.mu.Unlock()
+
+		if failure != nil {
+			if failure.Delay > 0 {
+				select {
+				case <-time.After(failure.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			if failure.Status != 0 {
+				for key, values := range failure.Header {
+					for _, v := range values {
+						w.Header().Add(key, v)
+					}
+				}
+				if failure.Body != "" {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(failure.Status)
+					io.WriteString(w, failure.Body)
+					return
+				}
+				writeError(w, failure.Status, "INJECTED_FAILURE", http.StatusText(failure.Status))
+				return
+			}
+		}
+
+		if r.URL.Path != "/oauth/token" && !s.authorized(w, r) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) matchFailureLocked(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if s.APIKey != "" && r.Header.Get("x-api-key") != s.APIKey {
+		writeError(w, http.StatusForbidden, "INVALID_API_KEY", "missing or invalid x-api-key header")
+		return false
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	valid := s.tokens[token]
+	s.mu.Unlock()
+
+	if !valid {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid or expired access token")
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, "invalid_request", err.Error())
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		if r.PostForm.Get("code") == "" {
+			writeOAuthError(w, "invalid_grant", "missing authorization code")
+			return
+		}
+
+		access, refresh := s.IssueTokens()
+		writeJSON(w, http.StatusOK, stockxgo.AuthResponse{
+			AccessToken:  access,
+			RefreshToken: refresh,
+			Scope:        stockxgo.DefaultScope,
+			ExpiresIn:    3600,
+			TokenType:    "Bearer",
+		})
+	case "refresh_token":
+		s.mu.Lock()
+		valid := s.refresh[r.PostForm.Get("refresh_token")]
+		s.mu.Unlock()
+
+		if !valid {
+			writeOAuthError(w, "invalid_grant", "unknown refresh token")
+			return
+		}
+
+		s.mu.Lock()
+		access := "access-" + s.newIDLocked()
+		s.tokens[access] = true
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, stockxgo.RefreshResponse{
+			AccessToken: access,
+			ExpiresIn:   3600,
+			Scope:       stockxgo.DefaultScope,
+			TokenType:   "Bearer",
+		})
+	default:
+		writeOAuthError(w, "unsupported_grant_type", r.PostForm.Get("grant_type"))
+	}
+}
+
+func (s *Server) newIDLocked() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{
+		"errorCode":    code,
+		"errorMessage": message,
+	})
+}
+
+func writeOAuthError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// paginate returns the page of items selected by the pageNumber and pageSize
+// query parameters.
+func paginate[T any](items []T, query url.Values, defaultPageSize int) (page []T, pageNumber, pageSize int, hasNextPage bool) {
+	pageNumber, err := strconv.Atoi(query.Get("pageNumber"))
+	if err != nil || pageNumber < 1 {
+		pageNumber = 1
+	}
+
+	pageSize, err = strconv.Atoi(query.Get("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	start := (pageNumber - 1) * pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := min(start+pageSize, len(items))
+	return items[start:end], pageNumber, pageSize, end < len(items)
+}
+
+// splitList parses a comma separated query parameter.
+func splitList(value string) map[string]bool {
+	if value == "" {
+		return nil
+	}
+
+	set := make(map[string]bool)
+	for _, v := range strings.Split(value, ",") {
+		set[strings.TrimSpace(v)] = true
+	}
+	return set
+}
+
+func notFound(w http.ResponseWriter, what, id string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s %s not found", what, id))
+}
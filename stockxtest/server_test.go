@@ -0,0 +1,239 @@
+package stockxtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	stockxgo "github.com/combo23/stockx-go"
+	"github.com/combo23/stockx-go/stockxtest"
+)
+
+// recordingTB captures the failures reported by the Assert helpers.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func statusOf(err error) int {
+	var apiErr *stockxgo.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func TestInjectTimesAndPathPrefix(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+	srv.AddListing(stockxgo.Listing{ListingID: "listing-1"})
+	client := srv.Client(stockxgo.WithRetryPolicy(stockxgo.NoRetryPolicy()))
+	defer client.Close()
+
+	srv.Inject(stockxtest.Failure{Method: "GET", Path: "/v2/catalog/", Status: http.StatusServiceUnavailable, Times: 2})
+
+	// Requests outside the prefix are served normally.
+	if _, err := client.GetListing("listing-1"); err != nil {
+		t.Fatalf("GetListing: %v", err)
+	}
+
+	for i := range 2 {
+		if _, err := client.GetSingleProduct("product-1"); statusOf(err) != http.StatusServiceUnavailable {
+			t.Fatalf("GetSingleProduct #%d error = %v, want 503", i+1, err)
+		}
+	}
+	if _, err := client.GetSingleProduct("product-1"); err != nil {
+		t.Fatalf("GetSingleProduct after the failure was used up: %v", err)
+	}
+
+	// A failure without Times applies until cleared.
+	srv.Inject(stockxtest.Failure{Path: "/v2/catalog/products/product-1", Status: http.StatusTeapot})
+	for range 3 {
+		if _, err := client.GetSingleProduct("product-1"); statusOf(err) != http.StatusTeapot {
+			t.Fatalf("GetSingleProduct error = %v, want 418", err)
+		}
+	}
+	srv.ClearFailures()
+	if _, err := client.GetSingleProduct("product-1"); err != nil {
+		t.Fatalf("GetSingleProduct after ClearFailures: %v", err)
+	}
+
+	srv.FailNext(http.StatusBadGateway)
+	if _, err := client.GetListing("listing-1"); statusOf(err) != http.StatusBadGateway {
+		t.Fatalf("GetListing error = %v, want 502", err)
+	}
+	if _, err := client.GetListing("listing-1"); err != nil {
+		t.Fatalf("GetListing after FailNext: %v", err)
+	}
+}
+
+func TestInjectDelay(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+	client := srv.Client(stockxgo.WithRetryPolicy(stockxgo.NoRetryPolicy()))
+	defer client.Close()
+
+	// A delay without a status slows the request down but still serves it.
+	srv.Inject(stockxtest.Failure{Path: "/v2/catalog/products/product-1", Delay: 100 * time.Millisecond, Times: 1})
+
+	start := time.Now()
+	if _, err := client.GetSingleProduct("product-1"); err != nil {
+		t.Fatalf("GetSingleProduct: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("delayed request took %s, want at least 100ms", elapsed)
+	}
+
+	// The delay gives up when the client does.
+	srv.Inject(stockxtest.Failure{Path: "/v2/catalog/products/product-1", Delay: 10 * time.Second, Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	if _, err := client.GetSingleProductContext(ctx, "product-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetSingleProductContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled request took %s", elapsed)
+	}
+}
+
+func TestAsyncOperations(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AsyncOperations = true
+	listing := srv.AddListing(stockxgo.Listing{Status: stockxgo.ListingStatusInactive})
+	client := srv.Client()
+	defer client.Close()
+
+	resp, err := client.DeactivateListing(listing.ListingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OperationStatus != stockxgo.OperationStatusPending {
+		t.Errorf("queued operation status = %s, want PENDING", resp.OperationStatus)
+	}
+
+	for _, want := range []stockxgo.OperationStatus{stockxgo.OperationStatusPending, stockxgo.OperationStatusSucceeded, stockxgo.OperationStatusSucceeded} {
+		op, err := client.GetListingOperation(listing.ListingID, resp.OperationID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.OperationStatus != want {
+			t.Errorf("operation status = %s, want %s", op.OperationStatus, want)
+		}
+	}
+
+	// A failed operation stays failed.
+	resp, err = client.DeactivateListing(listing.ListingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailOperation(listing.ListingID, resp.OperationID, "listing is locked")
+
+	op, err := client.GetListingOperation(listing.ListingID, resp.OperationID)
+	if err != nil || op.OperationStatus != stockxgo.OperationStatusFailed {
+		t.Errorf("failed operation = %s, %v; want FAILED", op.OperationStatus, err)
+	}
+
+	if ops := srv.Operations(listing.ListingID); len(ops) != 2 {
+		t.Errorf("Operations = %d, want 2", len(ops))
+	}
+}
+
+func TestListingsPagination(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	for range 5 {
+		srv.AddListing(stockxgo.Listing{Status: stockxgo.ListingStatusActive})
+	}
+	client := srv.Client()
+	defer client.Close()
+
+	tests := []struct {
+		page, size int
+		want       int
+		next       bool
+	}{
+		{page: 1, size: 2, want: 2, next: true},
+		{page: 3, size: 2, want: 1, next: false},
+		{page: 4, size: 2, want: 0, next: false},
+		{page: 1, size: 5, want: 5, next: false},
+	}
+
+	for _, tt := range tests {
+		resp, err := client.GetAllListings(stockxgo.WithGetAllListingsPageNumber(tt.page), stockxgo.WithGetAllListingsPageSize(tt.size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Listings) != tt.want || resp.HasNextPage != tt.next || resp.Count != 5 || resp.PageNumber != tt.page {
+			t.Errorf("page %d of %d = %d listings, hasNextPage %t, count %d, pageNumber %d; want %d, %t",
+				tt.page, tt.size, len(resp.Listings), resp.HasNextPage, resp.Count, resp.PageNumber, tt.want, tt.next)
+		}
+	}
+
+	var seen int
+	for _, err := range client.ListingsIter(context.Background(), stockxgo.WithGetAllListingsPageSize(2)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen++
+	}
+	if seen != 5 {
+		t.Errorf("ListingsIter yielded %d listings, want 5", seen)
+	}
+	srv.AssertRequestCount(t, "GET", "/v2/selling/listings", len(tests)+3)
+}
+
+func TestAssertHelpers(t *testing.T) {
+	srv := stockxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddProduct(stockxgo.Product{ProductID: "product-1"})
+	client := srv.Client()
+	defer client.Close()
+
+	if _, err := client.GetSingleProduct("product-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	passing := &recordingTB{TB: t}
+	req := srv.AssertRequested(passing, "GET", "/v2/catalog/products/product-1")
+	srv.AssertRequested(passing, "", "/v2/catalog/products/product-1")
+	srv.AssertNotRequested(passing, "POST", "/v2/catalog/products/product-1")
+	srv.AssertRequestCount(passing, "GET", "/v2/catalog/products/product-1", 1)
+	if len(passing.errors) > 0 {
+		t.Errorf("assertions that hold reported %q", passing.errors)
+	}
+	if req.Method != "GET" || req.Header.Get("Authorization") == "" {
+		t.Errorf("AssertRequested returned %s with Authorization %q", req.Method, req.Header.Get("Authorization"))
+	}
+
+	failing := &recordingTB{TB: t}
+	srv.AssertRequested(failing, "GET", "/v2/catalog/products/product-2")
+	srv.AssertNotRequested(failing, "GET", "/v2/catalog/products/product-1")
+	srv.AssertRequestCount(failing, "GET", "/v2/catalog/products/product-1", 2)
+	if len(failing.errors) != 3 {
+		t.Errorf("assertions that do not hold reported %d failures, want 3: %q", len(failing.errors), failing.errors)
+	}
+
+	srv.ResetRequests()
+	if requests := srv.Requests(); len(requests) != 0 {
+		t.Errorf("Requests after ResetRequests = %d, want 0", len(requests))
+	}
+}
@@ -0,0 +1,120 @@
+package stockxgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrNoSession = errors.New("no stored session")
+
+// TokenStore persists sessions so they survive restarts and can be shared
+// between processes. The client loads the stored session in Authenticate and
+// saves it after every successful authentication and token refresh.
+type TokenStore interface {
+	// Load returns the stored session, or ErrNoSession when there is none.
+	Load(ctx context.Context) (Session, error)
+	Save(ctx context.Context, session Session) error
+}
+
+// FileTokenStore stores a session as JSON in a single file. Writes are
+// atomic and the file is only readable by its owner.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (f *FileTokenStore) Load(ctx context.Context) (Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Session{}, ErrNoSession
+	}
+	if err != nil {
+		return Session{}, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return Session{}, err
+	}
+
+	return session, nil
+}
+
+func (f *FileTokenStore) Save(ctx context.Context, session Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, f.path)
+}
+
+// MemoryTokenStore keeps a session in memory. It is useful for sharing one
+// session between several clients in the same process.
+type MemoryTokenStore struct {
+	mu      sync.RWMutex
+	session *Session
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (m *MemoryTokenStore) Load(ctx context.Context) (Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.session == nil {
+		return Session{}, ErrNoSession
+	}
+
+	return *m.session, nil
+}
+
+func (m *MemoryTokenStore) Save(ctx context.Context, session Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.session = &session
+	return nil
+}
@@ -0,0 +1,165 @@
+package stockxgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+	store := NewFileTokenStore(path)
+
+	if _, err := store.Load(ctx); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("Load of a missing file = %v, want ErrNoSession", err)
+	}
+
+	// An existing file readable by others is replaced by a private one.
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Session{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3600, ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Second)}
+	if err := store.Save(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("session file permissions = %o, want 600", perm)
+	}
+
+	got, err := store.Load(ctx)
+	if err != nil || got != want {
+		t.Errorf("Load = %+v, %v; want %+v", got, err, want)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files after Save, want only the session file", len(entries))
+	}
+}
+
+func TestFileTokenStoreSaveIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.json")
+	store := NewFileTokenStore(path)
+
+	if err := store.Save(ctx, Session{AccessToken: "initial"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Readers going straight to the file must never see a partial write.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 50 {
+			token := strings.Repeat("x", 4096+i)
+			if err := store.Save(ctx, Session{AccessToken: token, RefreshToken: token}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var session Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			t.Fatalf("read a partially written session file: %v", err)
+		}
+	}
+}
+
+func TestAdoptStoredSession(t *testing.T) {
+	now := time.Now()
+	current := Session{AccessToken: "current", RefreshToken: "refresh", ExpiresAt: now.Add(30 * time.Second)}
+
+	tests := []struct {
+		name   string
+		stored *Session
+		want   bool
+	}{
+		{name: "nothing stored"},
+		{name: "same token", stored: &current},
+		{name: "no access token", stored: &Session{RefreshToken: "refresh", ExpiresAt: now.Add(time.Hour)}},
+		{name: "older", stored: &Session{AccessToken: "older", ExpiresAt: now.Add(10 * time.Second)}},
+		{name: "about to expire", stored: &Session{AccessToken: "newer", ExpiresAt: now.Add(45 * time.Second)}},
+		{name: "refreshed elsewhere", stored: &Session{AccessToken: "newer", RefreshToken: "refresh-2", ExpiresAt: now.Add(time.Hour)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryTokenStore()
+			if tt.stored != nil {
+				store.Save(context.Background(), *tt.stored)
+			}
+
+			client := New(WithSession(current), WithTokenStore(store)).(*stockXClient)
+			defer client.Close()
+
+			adopted, err := client.adoptStoredSession(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if adopted != tt.want {
+				t.Errorf("adopted = %t, want %t", adopted, tt.want)
+			}
+
+			want := current
+			if tt.want {
+				want = *tt.stored
+			}
+			if got := client.GetSession(); got != want {
+				t.Errorf("session = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	client := New(WithSession(current)).(*stockXClient)
+	defer client.Close()
+	if adopted, err := client.adoptStoredSession(context.Background()); adopted || err != nil {
+		t.Errorf("without a store: adopted = %t, %v", adopted, err)
+	}
+
+	broken := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(broken, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client = New(WithSession(current), WithTokenStore(NewFileTokenStore(broken))).(*stockXClient)
+	defer client.Close()
+	if _, err := client.adoptStoredSession(context.Background()); err == nil {
+		t.Error("an unreadable store did not fail adoptStoredSession")
+	}
+}
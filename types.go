@@ -0,0 +1,106 @@
+package stockxgo
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProductRef identifies the product of a listing or order
+type ProductRef struct {
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+	StyleID     string `json:"styleId"`
+}
+
+// VariantRef identifies the variant of a listing or order
+type VariantRef struct {
+	VariantID    string `json:"variantId"`
+	VariantName  string `json:"variantName"`
+	VariantValue string `json:"variantValue"`
+}
+
+// Payout is what the seller receives for a sale
+type Payout struct {
+	TotalPayout      Money              `json:"totalPayout"`
+	SalePrice        Money              `json:"salePrice"`
+	TotalAdjustments Money              `json:"totalAdjustments"`
+	CurrencyCode     string             `json:"currencyCode"`
+	Adjustments      []PayoutAdjustment `json:"adjustments"`
+}
+
+// UnmarshalJSON puts every payout amount in CurrencyCode.
+func (p *Payout) UnmarshalJSON(data []byte) error {
+	type payout Payout
+	if err := json.Unmarshal(data, (*payout)(p)); err != nil {
+		return err
+	}
+
+	p.TotalPayout = p.TotalPayout.WithCurrency(p.CurrencyCode)
+	p.SalePrice = p.SalePrice.WithCurrency(p.CurrencyCode)
+	p.TotalAdjustments = p.TotalAdjustments.WithCurrency(p.CurrencyCode)
+	for i := range p.Adjustments {
+		p.Adjustments[i].Amount = p.Adjustments[i].Amount.WithCurrency(p.CurrencyCode)
+	}
+
+	return nil
+}
+
+// PayoutAdjustment is a fee or credit applied to a payout
+type PayoutAdjustment struct {
+	AdjustmentType string  `json:"adjustmentType"`
+	Amount         Money   `json:"amount"`
+	Percentage     float64 `json:"percentage"`
+}
+
+// AuthenticationDetails holds the outcome of StockX's authentication of an
+// item
+type AuthenticationDetails struct {
+	Status       AuthenticationStatus `json:"status"`
+	FailureNotes string               `json:"failureNotes"`
+}
+
+// Shipment holds the shipping details of an order
+type Shipment struct {
+	ShipByDate          string `json:"shipByDate"`
+	TrackingNumber      string `json:"trackingNumber"`
+	TrackingURL         string `json:"trackingUrl"`
+	CarrierCode         string `json:"carrierCode"`
+	ShippingLabelURL    string `json:"shippingLabelUrl"`
+	ShippingDocumentURL string `json:"shippingDocumentUrl"`
+}
+
+// InitiatedShipments references the shipments a listing or order is part of
+type InitiatedShipments struct {
+	Inbound struct {
+		DisplayID string `json:"displayId"`
+	} `json:"inbound"`
+}
+
+// OperationChanges describes what a listing operation changed
+type OperationChanges struct {
+	Additions struct {
+		Active  bool `json:"active"`
+		AskData struct {
+			Amount    Money     `json:"amount"`
+			Currency  string    `json:"currency"`
+			ExpiresAt time.Time `json:"expiresAt"`
+		} `json:"askData"`
+	} `json:"additions"`
+	Updates struct {
+		UpdatedAt time.Time `json:"updatedAt"`
+	} `json:"updates"`
+	Removals struct {
+	} `json:"removals"`
+}
+
+// UnmarshalJSON puts the ask amount in the currency sent next to it.
+func (c *OperationChanges) UnmarshalJSON(data []byte) error {
+	type operationChanges OperationChanges
+	if err := json.Unmarshal(data, (*operationChanges)(c)); err != nil {
+		return err
+	}
+
+	c.Additions.AskData.Amount = c.Additions.AskData.Amount.WithCurrency(c.Additions.AskData.Currency)
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package stockxgo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultWatcherInterval = time.Minute
+	defaultWatcherJitter   = 0.1
+)
+
+// MarketChange is the part common to every market event: the variant it is
+// about and the two readings it was derived from.
+type MarketChange struct {
+	Key      MarketDataKey
+	Time     time.Time
+	Previous MarketData
+	Current  MarketData
+}
+
+// Change returns c. Through embedding it gives every market event access to
+// its MarketChange.
+func (c MarketChange) Change() MarketChange {
+	return c
+}
+
+// MarketEvent is emitted by a Watcher. It is one of LowestAskChanged,
+// HighestBidChanged or SpreadCrossedThreshold.
+type MarketEvent interface {
+	Change() MarketChange
+}
+
+// LowestAskChanged is emitted when the lowest ask of a variant moves, appears
+// or disappears. Unset amounts mean there was or is no ask.
+type LowestAskChanged struct {
+	MarketChange
+	Old Money
+	New Money
+}
+
+// HighestBidChanged is emitted when the highest bid of a variant moves,
+// appears or disappears. Unset amounts mean there was or is no bid.
+type HighestBidChanged struct {
+	MarketChange
+	Old Money
+	New Money
+}
+
+// SpreadCrossedThreshold is emitted when the gap between the lowest ask and
+// the highest bid moves from one side of the configured threshold to the
+// other.
+type SpreadCrossedThreshold struct {
+	MarketChange
+	Spread    Money
+	Threshold Money
+	// Above is true when the spread widened past the threshold and false when
+	// it narrowed to the threshold or below.
+	Above bool
+}
+
+// Watcher polls the market data of a set of variants and emits an event for
+// every change between two polls. The first poll only records a baseline.
+type Watcher struct {
+	client    StockXClient
+	keys      []MarketDataKey
+	interval  time.Duration
+	jitter    float64
+	threshold Money
+	onError   func(MarketDataKey, error)
+
+	last       map[MarketDataKey]MarketData
+	retryAfter time.Duration
+}
+
+type WatcherOption func(*Watcher)
+
+// WithWatcherInterval sets the time between two polls
+// Defaults to one minute
+func WithWatcherInterval(interval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.interval = interval
+	}
+}
+
+// WithWatcherJitter randomises every interval by up to the given fraction (0
+// to 1), so several watchers do not poll in lockstep
+// Defaults to 0.1
+func WithWatcherJitter(jitter float64) WatcherOption {
+	return func(w *Watcher) {
+		w.jitter = min(max(jitter, 0), 1)
+	}
+}
+
+// WithWatcherSpreadThreshold enables SpreadCrossedThreshold events. A
+// threshold without a currency applies to every watched currency.
+func WithWatcherSpreadThreshold(threshold Money) WatcherOption {
+	return func(w *Watcher) {
+		w.threshold = threshold
+	}
+}
+
+// WithWatcherErrorHandler is called with every market data request that
+// fails. Without a handler failed requests are only retried on the next
+// poll.
+func WithWatcherErrorHandler(handler func(MarketDataKey, error)) WatcherOption {
+	return func(w *Watcher) {
+		w.onError = handler
+	}
+}
+
+// NewWatcher returns a watcher for the given product, variant and currency
+// triples. An empty currency stands for the client's default currency.
+func NewWatcher(client StockXClient, keys []MarketDataKey, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		client:   client,
+		keys:     keys,
+		interval: defaultWatcherInterval,
+		jitter:   defaultWatcherJitter,
+		last:     make(map[MarketDataKey]MarketData),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Run polls until ctx ends and calls handler with every event, in order. A
+// Watcher must not run more than once at a time. Run returns the error of
+// ctx.
+func (w *Watcher) Run(ctx context.Context, handler func(MarketEvent)) error {
+	for {
+		events, _ := w.Poll(ctx)
+		for _, event := range events {
+			handler(event)
+		}
+
+		if err := sleepContext(ctx, w.nextDelay()); err != nil {
+			return err
+		}
+	}
+}
+
+// Events runs the watcher in the background and delivers its events on the
+// returned channel, which is closed once ctx ends.
+func (w *Watcher) Events(ctx context.Context) <-chan MarketEvent {
+	events := make(chan MarketEvent)
+
+	go func() {
+		defer close(events)
+
+		w.Run(ctx, func(event MarketEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events
+}
+
+// Poll fetches the market data of every watched variant once and returns the
+// events since the previous poll. It is what Run does on every tick, for
+// callers that schedule polls themselves. The returned error joins the
+// errors of the failed requests; the events of the others are still
+// returned.
+//
+// Variants of the same product and currency are fetched with a single
+// request. When StockX answers 429 the rest of the poll is skipped and the
+// next one waits at least as long as StockX asked.
+func (w *Watcher) Poll(ctx context.Context) ([]MarketEvent, error) {
+	w.retryAfter = 0
+
+	var events []MarketEvent
+	var errs []error
+	for _, group := range groupMarketDataKeys(w.keys) {
+		if ctx.Err() != nil {
+			return events, ctx.Err()
+		}
+
+		data, err := w.fetch(ctx, group)
+		if err != nil {
+			if ctx.Err() != nil {
+				return events, ctx.Err()
+			}
+
+			for _, key := range group {
+				w.reportError(key, err)
+			}
+			errs = append(errs, err)
+
+			if errors.Is(err, ErrTooManyRequests) {
+				var apiErr *APIError
+				if errors.As(err, &apiErr) {
+					w.retryAfter = apiErr.RetryAfter
+				}
+				break
+			}
+			continue
+		}
+
+		now := time.Now()
+		for _, key := range group {
+			current, ok := data[key.VariantID]
+			if !ok {
+				err := fmt.Errorf("no market data for variant %s of product %s", key.VariantID, key.ProductID)
+				w.reportError(key, err)
+				errs = append(errs, err)
+				continue
+			}
+
+			previous, seen := w.last[key]
+			w.last[key] = current
+			if seen {
+				key.CurrencyCode = cmp.Or(key.CurrencyCode, current.CurrencyCode)
+				events = append(events, w.diff(MarketChange{key, now, previous, current})...)
+			}
+		}
+	}
+
+	return events, errors.Join(errs...)
+}
+
+// fetch returns the market data of a group of keys sharing a product and
+// currency, by variant ID.
+func (w *Watcher) fetch(ctx context.Context, group []MarketDataKey) (map[string]MarketData, error) {
+	key := group[0]
+
+	if len(group) == 1 {
+		data, err := w.client.GetProductMarketDataForVariantContext(ctx, key.ProductID, key.VariantID, key.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]MarketData{key.VariantID: data}, nil
+	}
+
+	all, err := w.client.GetProductMarketDataContext(ctx, key.ProductID, key.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]MarketData, len(all))
+	for _, d := range all {
+		data[d.VariantID] = d
+	}
+
+	return data, nil
+}
+
+func (w *Watcher) diff(change MarketChange) []MarketEvent {
+	var events []MarketEvent
+
+	oldAsk, newAsk := change.Previous.LowestAskAmount, change.Current.LowestAskAmount
+	if amountChanged(oldAsk, newAsk) {
+		events = append(events, LowestAskChanged{change, oldAsk, newAsk})
+	}
+
+	oldBid, newBid := change.Previous.HighestBidAmount, change.Current.HighestBidAmount
+	if amountChanged(oldBid, newBid) {
+		events = append(events, HighestBidChanged{change, oldBid, newBid})
+	}
+
+	if !w.threshold.IsSet() {
+		return events
+	}
+
+	wasAbove, okPrevious := w.spreadAbove(change.Key, change.Previous)
+	isAbove, okCurrent := w.spreadAbove(change.Key, change.Current)
+	if okPrevious && okCurrent && wasAbove != isAbove {
+		spread, _ := newAsk.Sub(newBid)
+		events = append(events, SpreadCrossedThreshold{change, spread, w.threshold, isAbove})
+	}
+
+	return events
+}
+
+// spreadAbove reports whether the spread of data is above the threshold. The
+// second result is false when there is no spread to compare.
+func (w *Watcher) spreadAbove(key MarketDataKey, data MarketData) (bool, bool) {
+	if !data.LowestAskAmount.IsSet() || !data.HighestBidAmount.IsSet() {
+		return false, false
+	}
+
+	spread, err := data.LowestAskAmount.Sub(data.HighestBidAmount)
+	if err != nil {
+		w.reportError(key, err)
+		return false, false
+	}
+
+	c, err := spread.Cmp(w.threshold)
+	if err != nil {
+		w.reportError(key, err)
+		return false, false
+	}
+
+	return c > 0, true
+}
+
+func (w *Watcher) reportError(key MarketDataKey, err error) {
+	if w.onError != nil {
+		w.onError(key, err)
+	}
+}
+
+// nextDelay returns the jittered interval, stretched to honour a Retry-After
+// from the last poll or a rate limit bucket that is blocked for longer.
+func (w *Watcher) nextDelay() time.Duration {
+	delay := w.interval
+	if w.jitter > 0 {
+		delay += time.Duration(float64(delay) * w.jitter * (2*rand.Float64() - 1))
+	}
+
+	delay = max(delay, w.retryAfter)
+	if until := time.Until(w.client.RateLimitState()[EndpointFamilyCatalog].BlockedUntil); until > delay {
+		delay = until
+	}
+
+	return delay
+}
+
+func amountChanged(before, after Money) bool {
+	return before.IsSet() != after.IsSet() || !before.Equal(after)
+}
+
+// groupMarketDataKeys groups keys by product and currency, keeping the order
+// of first appearance and dropping duplicates.
+func groupMarketDataKeys(keys []MarketDataKey) [][]MarketDataKey {
+	type group struct{ productID, currencyCode string }
+
+	index := make(map[group]int)
+	seen := make(map[MarketDataKey]bool)
+
+	var groups [][]MarketDataKey
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		g := group{key.ProductID, key.CurrencyCode}
+		i, ok := index[g]
+		if !ok {
+			i = len(groups)
+			index[g] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], key)
+	}
+
+	return groups
+}